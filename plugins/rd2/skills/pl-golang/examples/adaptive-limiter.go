@@ -0,0 +1,95 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOverloaded is returned by AdaptiveLimiter.Do when the current
+// concurrency limit is already in use.
+var ErrOverloaded = errors.New("overloaded")
+
+var errAdaptivePanicked = errors.New("limited call panicked")
+
+// AdaptiveLimiter bounds concurrent calls with a limit that adapts to the
+// downstream using AIMD: every call that succeeds within the target latency
+// raises the limit by one, and every call that fails or runs slower than the
+// target halves it. Unlike a fixed Semaphore it backs off when the
+// downstream degrades and sheds excess load immediately instead of queueing.
+type AdaptiveLimiter struct {
+	minLimit, maxLimit int
+	target             time.Duration
+	clock              Clock
+
+	mu       sync.Mutex
+	limit    int
+	inFlight int
+}
+
+// NewAdaptiveLimiter returns a limiter that starts at maxLimit and stays
+// within [minLimit, maxLimit]. Calls slower than target count as congestion.
+// It panics if minLimit < 1 or maxLimit < minLimit.
+func NewAdaptiveLimiter(minLimit, maxLimit int, target time.Duration) *AdaptiveLimiter {
+	return newAdaptiveLimiterClock(minLimit, maxLimit, target, RealClock)
+}
+
+func newAdaptiveLimiterClock(minLimit, maxLimit int, target time.Duration, clock Clock) *AdaptiveLimiter {
+	if minLimit < 1 || maxLimit < minLimit {
+		panic("examples: adaptive limiter needs 1 <= minLimit <= maxLimit")
+	}
+	return &AdaptiveLimiter{
+		minLimit: minLimit,
+		maxLimit: maxLimit,
+		target:   target,
+		clock:    clock,
+		limit:    maxLimit,
+	}
+}
+
+// Do runs fn if a slot is free under the current limit and returns
+// ErrOverloaded otherwise. Failures caused by ctx ending are not held
+// against the downstream.
+func (l *AdaptiveLimiter) Do(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	if l.inFlight >= l.limit {
+		l.mu.Unlock()
+		return ErrOverloaded
+	}
+	l.inFlight++
+	l.mu.Unlock()
+
+	start := l.clock.Now()
+	defer func() { l.release(ctx, err, l.clock.Now().Sub(start)) }()
+	// Until fn returns normally, treat the call as failed so a panic still
+	// frees its slot and counts as congestion.
+	err = errAdaptivePanicked
+	return fn(ctx)
+}
+
+// release frees a slot and adjusts the limit for a call that finished with
+// err after latency.
+func (l *AdaptiveLimiter) release(ctx context.Context, err error, latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// The caller gave up; say nothing about the downstream.
+	case err != nil || latency > l.target:
+		l.limit = max(l.minLimit, l.limit/2)
+	default:
+		l.limit = min(l.maxLimit, l.limit+1)
+	}
+}
+
+// Limit reports the current concurrency limit.
+func (l *AdaptiveLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
@@ -0,0 +1,111 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAdaptiveLimiterAdjustsToLatency(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	l := newAdaptiveLimiterClock(1, 16, 100*time.Millisecond, clock)
+	call := func(latency time.Duration) error {
+		return l.Do(context.Background(), func(context.Context) error {
+			clock.Advance(latency)
+			return nil
+		})
+	}
+
+	// Rising latency halves the limit down to the floor.
+	for _, expected := range []int{8, 4, 2, 1, 1} {
+		if err := call(250 * time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := l.Limit(); got != expected {
+			t.Fatalf("expected limit %d after slow call, got %d", expected, got)
+		}
+	}
+
+	// Recovery grows it back one step per fast call, up to the ceiling.
+	for i := 0; i < 20; i++ {
+		if err := call(10 * time.Millisecond); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := l.Limit(); got != 16 {
+		t.Errorf("expected limit to recover to 16, got %d", got)
+	}
+}
+
+func TestAdaptiveLimiterErrorsShrinkLimit(t *testing.T) {
+	errDown := errors.New("downstream error")
+	l := newAdaptiveLimiterClock(2, 10, time.Second, NewFakeClock(time.Unix(0, 0)))
+
+	err := l.Do(context.Background(), func(context.Context) error { return errDown })
+	if !errors.Is(err, errDown) {
+		t.Fatalf("expected downstream error, got %v", err)
+	}
+	if got := l.Limit(); got != 5 {
+		t.Errorf("expected limit 5 after error, got %d", got)
+	}
+
+	// A caller giving up is not the downstream's fault.
+	ctx, cancel := context.WithCancel(context.Background())
+	err = l.Do(ctx, func(ctx context.Context) error {
+		cancel()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected Canceled, got %v", err)
+	}
+	if got := l.Limit(); got != 5 {
+		t.Errorf("expected limit unchanged after cancel, got %d", got)
+	}
+}
+
+func TestAdaptiveLimiterRejectsExcess(t *testing.T) {
+	l := newAdaptiveLimiterClock(1, 2, time.Second, NewFakeClock(time.Unix(0, 0)))
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			done <- l.Do(context.Background(), func(context.Context) error {
+				started <- struct{}{}
+				<-release
+				return nil
+			})
+		}()
+	}
+	<-started
+	<-started
+
+	if err := l.Do(context.Background(), func(context.Context) error { return nil }); !errors.Is(err, ErrOverloaded) {
+		t.Errorf("expected ErrOverloaded, got %v", err)
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if err := l.Do(context.Background(), func(context.Context) error { return nil }); err != nil {
+		t.Errorf("expected slot free after calls finished, got %v", err)
+	}
+}
+
+func TestAdaptiveLimiterPanicFreesSlot(t *testing.T) {
+	l := newAdaptiveLimiterClock(1, 1, time.Second, NewFakeClock(time.Unix(0, 0)))
+
+	func() {
+		defer func() { _ = recover() }()
+		_ = l.Do(context.Background(), func(context.Context) error { panic("boom") })
+	}()
+
+	if err := l.Do(context.Background(), func(context.Context) error { return nil }); err != nil {
+		t.Errorf("expected the panicked call's slot to be freed, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package examples
+
+import (
+	"cmp"
+	"testing"
+	"time"
+)
+
+// AssertMonotonic fails t if vals is not non-decreasing, reporting the first
+// out-of-order pair.
+func AssertMonotonic[T cmp.Ordered](t testing.TB, vals []T) {
+	t.Helper()
+	for i := 1; i < len(vals); i++ {
+		if vals[i] < vals[i-1] {
+			t.Errorf("out of order at index %d: %v follows %v", i, vals[i], vals[i-1])
+			return
+		}
+	}
+}
+
+// AssertChanClosed fails t unless ch is closed within timeout. A value
+// arriving first is reported as a failure.
+func AssertChanClosed[T any](t testing.TB, ch <-chan T, timeout time.Duration) {
+	t.Helper()
+	select {
+	case v, ok := <-ch:
+		if ok {
+			t.Errorf("expected channel to be closed, received value %v", v)
+		}
+	case <-time.After(timeout):
+		t.Errorf("channel not closed within %v", timeout)
+	}
+}
+
+// AssertRecv fails t unless want is received from ch within timeout,
+// distinguishing a wrong value from a closed channel.
+func AssertRecv[T comparable](t testing.TB, ch <-chan T, want T, timeout time.Duration) {
+	t.Helper()
+	select {
+	case v, ok := <-ch:
+		if !ok {
+			t.Errorf("expected value %v, channel was closed", want)
+			return
+		}
+		if v != want {
+			t.Errorf("received %v, want %v", v, want)
+		}
+	case <-time.After(timeout):
+		t.Errorf("no value received within %v, want %v", timeout, want)
+	}
+}
@@ -0,0 +1,133 @@
+package examples
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeT records failures so assertion helpers can be tested without failing
+// the enclosing test.
+type fakeT struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.failed = true
+	f.msg = fmt.Sprintf(format, args...)
+}
+
+func TestAssertMonotonic(t *testing.T) {
+	tests := []struct {
+		name       string
+		vals       []int
+		expectFail bool
+	}{
+		{"empty", nil, false},
+		{"single", []int{7}, false},
+		{"strictly increasing", []int{1, 2, 3, 4}, false},
+		{"with duplicates", []int{1, 1, 2, 2, 3}, false},
+		{"decrease at end", []int{1, 2, 3, 2}, true},
+		{"decrease at start", []int{5, 1, 2}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := &fakeT{}
+			AssertMonotonic(ft, tt.vals)
+			if ft.failed != tt.expectFail {
+				t.Errorf("failed=%v, want %v (msg: %q)", ft.failed, tt.expectFail, ft.msg)
+			}
+		})
+	}
+}
+
+func TestAssertMonotonicStrings(t *testing.T) {
+	ft := &fakeT{}
+	AssertMonotonic(ft, []string{"a", "c", "b"})
+	if !ft.failed {
+		t.Fatal("expected failure for unsorted strings")
+	}
+	if ft.msg != "out of order at index 2: b follows c" {
+		t.Errorf("unexpected message: %s", ft.msg)
+	}
+}
+
+func TestAssertChanClosed(t *testing.T) {
+	closed := make(chan int)
+	close(closed)
+
+	withValue := make(chan int, 1)
+	withValue <- 7
+
+	tests := []struct {
+		name    string
+		ch      chan int
+		wantMsg string
+	}{
+		{"closed", closed, ""},
+		{"value received", withValue, "received value 7"},
+		{"timeout", make(chan int), "not closed within"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := &fakeT{}
+			AssertChanClosed(ft, tt.ch, 10*time.Millisecond)
+			checkFakeT(t, ft, tt.wantMsg)
+		})
+	}
+}
+
+func TestAssertRecv(t *testing.T) {
+	value := func(v int) chan int {
+		ch := make(chan int, 1)
+		ch <- v
+		return ch
+	}
+	closed := make(chan int)
+	close(closed)
+
+	tests := []struct {
+		name    string
+		ch      chan int
+		wantMsg string
+	}{
+		{"value received", value(3), ""},
+		{"wrong value", value(4), "received 4, want 3"},
+		{"closed", closed, "channel was closed"},
+		{"timeout", make(chan int), "no value received"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := &fakeT{}
+			AssertRecv(ft, tt.ch, 3, 10*time.Millisecond)
+			checkFakeT(t, ft, tt.wantMsg)
+		})
+	}
+}
+
+// checkFakeT asserts ft passed when wantMsg is empty, or failed with a
+// message containing wantMsg.
+func checkFakeT(t *testing.T, ft *fakeT, wantMsg string) {
+	t.Helper()
+	if wantMsg == "" {
+		if ft.failed {
+			t.Errorf("unexpected failure: %s", ft.msg)
+		}
+		return
+	}
+	if !ft.failed {
+		t.Errorf("expected failure containing %q", wantMsg)
+		return
+	}
+	if !strings.Contains(ft.msg, wantMsg) {
+		t.Errorf("expected message containing %q, got %q", wantMsg, ft.msg)
+	}
+}
@@ -0,0 +1,76 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrBarrierBroken is returned to waiters released because another party
+// abandoned the barrier before it tripped.
+var ErrBarrierBroken = errors.New("barrier broken")
+
+// Barrier is a cyclic barrier: each round, Wait blocks until parties
+// goroutines have arrived, then releases them all and resets for the next
+// round. If any waiter's context is done first, the round is broken: that
+// waiter gets ctx.Err() and every other waiter in the round gets
+// ErrBarrierBroken, so no party is left blocked on a peer that gave up.
+type Barrier struct {
+	parties int
+
+	mu    sync.Mutex
+	round *barrierRound
+}
+
+type barrierRound struct {
+	arrived int
+	done    chan struct{}
+	err     error
+}
+
+// NewBarrier returns a Barrier for parties goroutines. It panics if parties
+// is less than 1.
+func NewBarrier(parties int) *Barrier {
+	if parties < 1 {
+		panic("examples: NewBarrier parties must be at least 1")
+	}
+	return &Barrier{parties: parties, round: newBarrierRound()}
+}
+
+func newBarrierRound() *barrierRound {
+	return &barrierRound{done: make(chan struct{})}
+}
+
+// Wait blocks until all parties have called Wait for the current round.
+func (b *Barrier) Wait(ctx context.Context) error {
+	b.mu.Lock()
+	round := b.round
+	round.arrived++
+	if round.arrived == b.parties {
+		b.advance()
+		b.mu.Unlock()
+		return nil
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-round.done:
+		return round.err
+	case <-ctx.Done():
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if b.round != round {
+			// The round tripped or broke while we were being canceled.
+			return round.err
+		}
+		round.err = ErrBarrierBroken
+		b.advance()
+		return ctx.Err()
+	}
+}
+
+// advance releases the current round and starts a new one. b.mu must be held.
+func (b *Barrier) advance() {
+	close(b.round.done)
+	b.round = newBarrierRound()
+}
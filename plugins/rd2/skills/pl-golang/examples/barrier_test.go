@@ -0,0 +1,101 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBarrierReleasesWhenAllArrive(t *testing.T) {
+	const parties = 4
+	b := NewBarrier(parties)
+
+	var released atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < parties-1; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := b.Wait(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			released.Add(1)
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if n := released.Load(); n != 0 {
+		t.Fatalf("expected waiters to block until the last party arrives, %d released", n)
+	}
+
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wg.Wait()
+	if n := released.Load(); n != parties-1 {
+		t.Errorf("expected %d released, got %d", parties-1, n)
+	}
+}
+
+func TestBarrierIsCyclic(t *testing.T) {
+	b := NewBarrier(2)
+	for round := 0; round < 3; round++ {
+		errs := make(chan error, 1)
+		go func() { errs <- b.Wait(context.Background()) }()
+		if err := b.Wait(context.Background()); err != nil {
+			t.Fatalf("round %d: unexpected error: %v", round, err)
+		}
+		if err := <-errs; err != nil {
+			t.Fatalf("round %d: unexpected error: %v", round, err)
+		}
+	}
+}
+
+func TestBarrierCancelBreaksRound(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	b := NewBarrier(3)
+
+	errs := make(chan error, 1)
+	go func() { errs <- b.Wait(context.Background()) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := b.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, ErrBarrierBroken) {
+			t.Errorf("expected ErrBarrierBroken, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("peer waiter was not released after cancellation")
+	}
+	assertNoGoroutineLeak(t, baseline)
+
+	// The next round starts fresh.
+	go func() { errs <- b.Wait(context.Background()) }()
+	go func() { errs <- b.Wait(context.Background()) }()
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestNewBarrierPanicsOnInvalidParties(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for zero parties")
+		}
+	}()
+	NewBarrier(0)
+}
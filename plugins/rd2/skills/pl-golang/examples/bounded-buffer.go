@@ -0,0 +1,96 @@
+package examples
+
+import (
+	"context"
+	"sync"
+)
+
+// OverflowPolicy decides what BoundedBuffer.Add does when the buffer is full.
+type OverflowPolicy int
+
+const (
+	// DropOldest evicts the oldest item to make room for the new one.
+	DropOldest OverflowPolicy = iota
+	// DropNewest discards the item being added.
+	DropNewest
+	// Block waits until Flush frees space or the context is done.
+	Block
+)
+
+// BoundedBuffer collects items up to a fixed capacity for periodic flushing,
+// e.g. log or metric batches that must never grow without bound.
+type BoundedBuffer[T any] struct {
+	mu      sync.Mutex
+	items   []T
+	cap     int
+	policy  OverflowPolicy
+	dropped int
+	space   chan struct{} // closed and replaced on every Flush
+}
+
+// NewBoundedBuffer returns an empty buffer holding at most capacity items.
+// It panics if capacity is not positive.
+func NewBoundedBuffer[T any](capacity int, policy OverflowPolicy) *BoundedBuffer[T] {
+	if capacity <= 0 {
+		panic("examples: bounded buffer capacity must be positive")
+	}
+	return &BoundedBuffer[T]{
+		items:  make([]T, 0, capacity),
+		cap:    capacity,
+		policy: policy,
+		space:  make(chan struct{}),
+	}
+}
+
+// Add appends v, applying the overflow policy if the buffer is full. Only the
+// Block policy can fail, returning ctx.Err() if ctx is done while waiting.
+func (b *BoundedBuffer[T]) Add(ctx context.Context, v T) error {
+	for {
+		b.mu.Lock()
+		if len(b.items) < b.cap {
+			b.items = append(b.items, v)
+			b.mu.Unlock()
+			return nil
+		}
+
+		switch b.policy {
+		case DropOldest:
+			copy(b.items, b.items[1:])
+			b.items[len(b.items)-1] = v
+			b.dropped++
+			b.mu.Unlock()
+			return nil
+		case DropNewest:
+			b.dropped++
+			b.mu.Unlock()
+			return nil
+		}
+
+		space := b.space
+		b.mu.Unlock()
+		select {
+		case <-space:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Flush removes and returns all buffered items, oldest first, waking any
+// blocked Add calls.
+func (b *BoundedBuffer[T]) Flush() []T {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := b.items
+	b.items = make([]T, 0, b.cap)
+	close(b.space)
+	b.space = make(chan struct{})
+	return out
+}
+
+// Dropped returns how many items the drop policies have discarded.
+func (b *BoundedBuffer[T]) Dropped() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
@@ -0,0 +1,98 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBoundedBufferPolicies(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      OverflowPolicy
+		expected    []int
+		wantDropped int
+	}{
+		{"drop oldest", DropOldest, []int{3, 4, 5}, 2},
+		{"drop newest", DropNewest, []int{1, 2, 3}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBoundedBuffer[int](3, tt.policy)
+			for i := 1; i <= 5; i++ {
+				if err := b.Add(context.Background(), i); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			if got := b.Flush(); !slices.Equal(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+			if b.Dropped() != tt.wantDropped {
+				t.Errorf("expected %d dropped, got %d", tt.wantDropped, b.Dropped())
+			}
+			if got := b.Flush(); len(got) != 0 {
+				t.Errorf("expected empty buffer after flush, got %v", got)
+			}
+		})
+	}
+}
+
+func TestBoundedBufferBlockWaitsForFlush(t *testing.T) {
+	b := NewBoundedBuffer[string](1, Block)
+	_ = b.Add(context.Background(), "a")
+
+	done := make(chan error, 1)
+	go func() { done <- b.Add(context.Background(), "b") }()
+
+	select {
+	case <-done:
+		t.Fatal("Add should block while the buffer is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if got := b.Flush(); !slices.Equal(got, []string{"a"}) {
+		t.Fatalf("expected [a], got %v", got)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Add did not unblock after Flush")
+	}
+	if got := b.Flush(); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("expected [b], got %v", got)
+	}
+}
+
+func TestBoundedBufferBlockRespectsContext(t *testing.T) {
+	b := NewBoundedBuffer[int](1, Block)
+	_ = b.Add(context.Background(), 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := b.Add(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if b.Dropped() != 0 {
+		t.Errorf("expected Block to never drop, got %d", b.Dropped())
+	}
+}
+
+func TestNewBoundedBufferPanicsOnNonPositiveCapacity(t *testing.T) {
+	for _, capacity := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for capacity %d", capacity)
+				}
+			}()
+			NewBoundedBuffer[int](capacity, DropOldest)
+		}()
+	}
+}
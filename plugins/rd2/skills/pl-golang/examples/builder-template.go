@@ -0,0 +1,81 @@
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Endpoint is the value produced by EndpointBuilder. Replace it with the type
+// being built.
+type Endpoint struct {
+	Name    string
+	URL     string
+	Timeout time.Duration
+	Retries int
+}
+
+// EndpointBuilder assembles an Endpoint through chained With* calls and
+// validates everything at once in Build.
+//
+// Example:
+//
+//	ep, err := NewEndpointBuilder().
+//		WithName("billing").
+//		WithURL("https://billing.internal").
+//		WithRetries(3).
+//		Build()
+type EndpointBuilder struct {
+	ep Endpoint
+}
+
+// NewEndpointBuilder returns a builder with defaults for optional fields.
+func NewEndpointBuilder() *EndpointBuilder {
+	return &EndpointBuilder{ep: Endpoint{Timeout: 10 * time.Second}}
+}
+
+// WithName sets the required endpoint name.
+func (b *EndpointBuilder) WithName(name string) *EndpointBuilder {
+	b.ep.Name = name
+	return b
+}
+
+// WithURL sets the required endpoint URL.
+func (b *EndpointBuilder) WithURL(url string) *EndpointBuilder {
+	b.ep.URL = url
+	return b
+}
+
+// WithTimeout overrides the default request timeout.
+func (b *EndpointBuilder) WithTimeout(d time.Duration) *EndpointBuilder {
+	b.ep.Timeout = d
+	return b
+}
+
+// WithRetries sets how many times a failed request is retried.
+func (b *EndpointBuilder) WithRetries(n int) *EndpointBuilder {
+	b.ep.Retries = n
+	return b
+}
+
+// Build validates the accumulated fields and returns the Endpoint. All
+// problems are reported together, joined with errors.Join.
+func (b *EndpointBuilder) Build() (Endpoint, error) {
+	var errs []error
+	if b.ep.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if b.ep.URL == "" {
+		errs = append(errs, errors.New("url is required"))
+	}
+	if b.ep.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("timeout must be positive, got %v", b.ep.Timeout))
+	}
+	if b.ep.Retries < 0 {
+		errs = append(errs, fmt.Errorf("retries must be non-negative, got %d", b.ep.Retries))
+	}
+	if len(errs) > 0 {
+		return Endpoint{}, fmt.Errorf("build endpoint: %w", errors.Join(errs...))
+	}
+	return b.ep, nil
+}
@@ -0,0 +1,56 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEndpointBuilderFullBuild(t *testing.T) {
+	ep, err := NewEndpointBuilder().
+		WithName("billing").
+		WithURL("https://billing.internal").
+		WithTimeout(time.Second).
+		WithRetries(3).
+		Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := Endpoint{Name: "billing", URL: "https://billing.internal", Timeout: time.Second, Retries: 3}
+	if ep != want {
+		t.Errorf("expected %+v, got %+v", want, ep)
+	}
+}
+
+func TestEndpointBuilderDefaults(t *testing.T) {
+	ep, err := NewEndpointBuilder().WithName("a").WithURL("http://a").Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ep.Timeout != 10*time.Second {
+		t.Errorf("expected default timeout 10s, got %v", ep.Timeout)
+	}
+}
+
+func TestEndpointBuilderMissingRequired(t *testing.T) {
+	_, err := NewEndpointBuilder().WithRetries(-1).Build()
+
+	if err == nil {
+		t.Fatal("expected error for missing required fields")
+	}
+	for _, want := range []string{"name is required", "url is required", "retries must be non-negative"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %q, got: %s", want, err.Error())
+		}
+	}
+}
+
+func TestEndpointBuilderChainingReturnsSameBuilder(t *testing.T) {
+	b := NewEndpointBuilder()
+
+	if b.WithName("x") != b || b.WithURL("y") != b || b.WithTimeout(time.Second) != b || b.WithRetries(1) != b {
+		t.Error("expected every With* method to return the same builder")
+	}
+}
@@ -0,0 +1,85 @@
+package examples
+
+import (
+	"context"
+	"maps"
+	"sync"
+)
+
+// Bulkhead partitions concurrency across named groups so a dependency that
+// hangs can only exhaust its own slots. Each group has a reserved number of
+// slots nobody else can use, and any group may borrow from a shared overflow
+// pool once its reservation is full.
+type Bulkhead struct {
+	mu           sync.Mutex
+	cond         *CondVar
+	reserved     map[string]int
+	inUse        map[string]int
+	overflow     int
+	overflowUsed int
+}
+
+// NewBulkhead returns a Bulkhead with the given per-group reservations and
+// overflow pool size. Groups missing from reserved may only use the
+// overflow. It panics on negative sizes.
+func NewBulkhead(reserved map[string]int, overflow int) *Bulkhead {
+	if overflow < 0 {
+		panic("examples: bulkhead overflow must not be negative")
+	}
+	for _, n := range reserved {
+		if n < 0 {
+			panic("examples: bulkhead reservation must not be negative")
+		}
+	}
+	b := &Bulkhead{
+		reserved: maps.Clone(reserved),
+		inUse:    make(map[string]int),
+		overflow: overflow,
+	}
+	b.cond = NewCondVar(&b.mu)
+	return b
+}
+
+// Execute runs fn in a slot for group, waiting for one of the group's
+// reserved slots or a shared overflow slot to free up. It returns ctx.Err()
+// without running fn if ctx is done first.
+func (b *Bulkhead) Execute(ctx context.Context, group string, fn func(ctx context.Context) error) error {
+	borrowed, err := b.acquire(ctx, group)
+	if err != nil {
+		return err
+	}
+	defer b.release(group, borrowed)
+	return fn(ctx)
+}
+
+func (b *Bulkhead) acquire(ctx context.Context, group string) (borrowed bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		if b.inUse[group] < b.reserved[group] {
+			b.inUse[group]++
+			return false, nil
+		}
+		if b.overflowUsed < b.overflow {
+			b.overflowUsed++
+			return true, nil
+		}
+		if err := b.cond.Wait(ctx); err != nil {
+			return false, err
+		}
+	}
+}
+
+func (b *Bulkhead) release(group string, borrowed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if borrowed {
+		b.overflowUsed--
+	} else {
+		b.inUse[group]--
+	}
+	b.cond.Broadcast()
+}
@@ -0,0 +1,109 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBulkheadIsolatesGroups(t *testing.T) {
+	b := NewBulkhead(map[string]int{"db": 1, "cache": 1}, 1)
+
+	// Saturate db: its reserved slot plus the shared overflow.
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			done <- b.Execute(context.Background(), "db", func(context.Context) error {
+				started <- struct{}{}
+				<-release
+				return nil
+			})
+		}()
+	}
+	<-started
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	ran := false
+	if err := b.Execute(ctx, "cache", func(context.Context) error { ran = true; return nil }); err != nil {
+		t.Fatalf("expected cache to use its reserved slot, got %v", err)
+	}
+	if !ran {
+		t.Error("expected cache call to run")
+	}
+
+	// A third db call queues until a db slot frees.
+	queued := make(chan error, 1)
+	go func() {
+		queued <- b.Execute(context.Background(), "db", func(context.Context) error { return nil })
+	}()
+	select {
+	case err := <-queued:
+		t.Fatalf("expected db call to queue, got %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	select {
+	case err := <-queued:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected queued db call to run after slots freed")
+	}
+}
+
+func TestBulkheadCanceledWhileQueued(t *testing.T) {
+	b := NewBulkhead(map[string]int{"db": 1}, 0)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go b.Execute(context.Background(), "db", func(context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	ran := make(chan struct{}, 1)
+	go func() {
+		errs <- b.Execute(ctx, "db", func(context.Context) error {
+			ran <- struct{}{}
+			return nil
+		})
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Canceled, got %v", err)
+	}
+	select {
+	case <-ran:
+		t.Error("expected canceled call not to run")
+	default:
+	}
+}
+
+func TestBulkheadUnknownGroupUsesOverflow(t *testing.T) {
+	b := NewBulkhead(map[string]int{"db": 1}, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := b.Execute(ctx, "search", func(context.Context) error { return nil })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected group without reservation or overflow to wait, got %v", err)
+	}
+}
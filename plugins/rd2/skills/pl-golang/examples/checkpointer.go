@@ -0,0 +1,130 @@
+package examples
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Checkpointer persists a long-running worker's progress so it can resume
+// after an interruption, without saving on every step. Checkpoint records the
+// latest state; saves happen in the background at most once per interval,
+// always with the newest state. When ctx is done, any unsaved state is saved
+// one final time, under a context that is no longer canceled.
+type Checkpointer[S any] struct {
+	save     func(context.Context, S) error
+	interval time.Duration
+	clock    Clock
+	kick     chan struct{}
+	done     chan struct{}
+
+	mu      sync.Mutex
+	pending S
+	dirty   bool
+	err     error
+}
+
+// NewCheckpointer starts a Checkpointer that persists state through save at
+// most once per interval. It panics if interval is not positive.
+func NewCheckpointer[S any](ctx context.Context, save func(context.Context, S) error, interval time.Duration) *Checkpointer[S] {
+	return newCheckpointerClock(ctx, save, interval, RealClock)
+}
+
+func newCheckpointerClock[S any](ctx context.Context, save func(context.Context, S) error, interval time.Duration, clock Clock) *Checkpointer[S] {
+	if interval <= 0 {
+		panic("examples: checkpointer interval must be positive")
+	}
+	c := &Checkpointer[S]{
+		save:     save,
+		interval: interval,
+		clock:    clock,
+		kick:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	go c.run(ctx)
+	return c
+}
+
+// Checkpoint records state as the latest progress. It never blocks on save.
+func (c *Checkpointer[S]) Checkpoint(state S) {
+	c.mu.Lock()
+	c.pending = state
+	c.dirty = true
+	c.mu.Unlock()
+
+	select {
+	case c.kick <- struct{}{}:
+	default: // a save is already requested
+	}
+}
+
+// Err returns the error from the most recent save, or nil if it succeeded.
+// A state whose save failed is retried by the next save.
+func (c *Checkpointer[S]) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// Wait blocks until the final save after ctx is done has finished and
+// returns its error.
+func (c *Checkpointer[S]) Wait() error {
+	<-c.done
+	return c.Err()
+}
+
+func (c *Checkpointer[S]) run(ctx context.Context) {
+	defer close(c.done)
+
+	var lastSave time.Time
+	var timer Timer
+	var due <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			c.flush(context.WithoutCancel(ctx))
+			return
+		case <-c.kick:
+			if due != nil {
+				continue // a save is already scheduled and will pick this up
+			}
+			wait := c.interval - c.clock.Now().Sub(lastSave)
+			if lastSave.IsZero() || wait <= 0 {
+				c.flush(ctx)
+				lastSave = c.clock.Now()
+				continue
+			}
+			timer = c.clock.NewTimer(wait)
+			due = timer.C()
+		case <-due:
+			timer, due = nil, nil
+			c.flush(ctx)
+			lastSave = c.clock.Now()
+		}
+	}
+}
+
+func (c *Checkpointer[S]) flush(ctx context.Context) {
+	c.mu.Lock()
+	if !c.dirty {
+		c.mu.Unlock()
+		return
+	}
+	state := c.pending
+	c.dirty = false
+	c.mu.Unlock()
+
+	err := c.save(ctx, state)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+	if err != nil && !c.dirty {
+		// Keep the failed state so the next save retries it.
+		c.pending = state
+		c.dirty = true
+	}
+}
@@ -0,0 +1,123 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// recordingSaver records saved states on a channel and fails while err is set.
+type recordingSaver struct {
+	saved chan int
+	err   error
+}
+
+func newRecordingSaver() *recordingSaver {
+	return &recordingSaver{saved: make(chan int, 10)}
+}
+
+func (r *recordingSaver) save(ctx context.Context, state int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.saved <- state
+	return r.err
+}
+
+func (r *recordingSaver) expectSave(t *testing.T, want int) {
+	t.Helper()
+	select {
+	case got := <-r.saved:
+		if got != want {
+			t.Errorf("expected save of %d, got %d", want, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected save of %d", want)
+	}
+}
+
+func (r *recordingSaver) expectNoSave(t *testing.T) {
+	t.Helper()
+	select {
+	case got := <-r.saved:
+		t.Errorf("unexpected save of %d", got)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestCheckpointerDebounces(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := newRecordingSaver()
+	c := newCheckpointerClock(ctx, r.save, time.Minute, clock)
+
+	c.Checkpoint(1)
+	r.expectSave(t, 1) // the first checkpoint saves right away
+
+	c.Checkpoint(2)
+	c.Checkpoint(3)
+	r.expectNoSave(t)
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	r.expectSave(t, 3) // only the newest state is saved
+	r.expectNoSave(t)
+}
+
+func TestCheckpointerFinalSaveOnCancel(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	r := newRecordingSaver()
+	c := newCheckpointerClock(ctx, r.save, time.Minute, clock)
+
+	c.Checkpoint(1)
+	r.expectSave(t, 1)
+	c.Checkpoint(2)
+	clock.BlockUntil(1)
+
+	cancel()
+	if err := c.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r.expectSave(t, 2)
+	if n := clock.Pending(); n != 0 {
+		t.Errorf("expected timer to be stopped, %d pending", n)
+	}
+}
+
+func TestCheckpointerSurfacesSaveErrors(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	errDisk := errors.New("disk full")
+	r := newRecordingSaver()
+	r.err = errDisk
+	c := newCheckpointerClock(ctx, r.save, time.Minute, clock)
+
+	c.Checkpoint(1)
+	r.expectSave(t, 1)
+	deadline := time.Now().Add(time.Second)
+	for !errors.Is(c.Err(), errDisk) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected Err to report the save failure, got %v", c.Err())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// The failed state is retried by the final save.
+	cancel()
+	if err := c.Wait(); !errors.Is(err, errDisk) {
+		t.Errorf("expected final save error, got %v", err)
+	}
+	r.expectSave(t, 1)
+}
+
+func TestNewCheckpointerInvalidInterval(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a zero interval")
+		}
+	}()
+	newCheckpointerClock(context.Background(), func(context.Context, int) error { return nil }, 0, NewFakeClock(time.Unix(0, 0)))
+}
@@ -0,0 +1,143 @@
+package examples
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when a CircuitBreaker rejects a call.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+//go:generate stringer -type=BreakerState -trimprefix=State
+
+const (
+	StateClosed BreakerState = iota
+	StateOpen
+	StateHalfOpen
+)
+
+// CircuitBreaker stops calls to a failing dependency. After threshold
+// consecutive failures it opens and rejects calls for cooldown, then admits
+// a limited number of concurrent probes (half-open): enough successful
+// probes close it, and any failed probe reopens it. By default a single
+// probe is admitted and one success closes the breaker; see
+// WithHalfOpenLimits.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	clock     Clock
+	maxProbes int
+	successes int // successful probes required to close
+
+	state     BreakerState
+	failures  int
+	openedAt  time.Time
+	probes    int // probes in flight
+	succeeded int // successful probes since entering half-open
+}
+
+// NewCircuitBreaker returns a closed breaker. A nil clock means RealClock.
+func NewCircuitBreaker(threshold int, cooldown time.Duration, clock Clock) *CircuitBreaker {
+	if clock == nil {
+		clock = RealClock
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		clock:     clock,
+		maxProbes: 1,
+		successes: 1,
+	}
+}
+
+// WithHalfOpenLimits lets up to maxProbes calls through concurrently while
+// half-open, rejecting the rest with ErrCircuitOpen, and requires successes
+// successful probes before closing. This keeps a burst of callers from
+// overwhelming a dependency that is just recovering. Values below 1 are
+// treated as 1. Call it before the breaker is shared; it returns b for
+// chaining.
+func (b *CircuitBreaker) WithHalfOpenLimits(maxProbes, successes int) *CircuitBreaker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.maxProbes = max(maxProbes, 1)
+	b.successes = max(successes, 1)
+	return b
+}
+
+// State returns the current state, moving from open to half-open once the
+// cooldown has elapsed.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance()
+	return b.state
+}
+
+// Allow reports whether a call may proceed, returning ErrCircuitOpen if not.
+// Every allowed call must be followed by Record.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance()
+
+	switch b.state {
+	case StateOpen:
+		return ErrCircuitOpen
+	case StateHalfOpen:
+		if b.probes >= b.maxProbes {
+			return ErrCircuitOpen
+		}
+		b.probes++
+	}
+	return nil
+}
+
+// Record reports the outcome of a call admitted by Allow.
+func (b *CircuitBreaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		switch b.state {
+		case StateClosed:
+			b.failures = 0
+		case StateHalfOpen:
+			b.probes = max(b.probes-1, 0)
+			b.succeeded++
+			if b.succeeded >= b.successes {
+				b.state = StateClosed
+				b.failures = 0
+			}
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.threshold {
+		b.state = StateOpen
+		b.openedAt = b.clock.Now()
+	}
+}
+
+// Execute runs fn if the breaker allows it and records the result.
+func (b *CircuitBreaker) Execute(fn func() error) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+	err := fn()
+	b.Record(err)
+	return err
+}
+
+func (b *CircuitBreaker) advance() {
+	if b.state == StateOpen && b.clock.Now().Sub(b.openedAt) >= b.cooldown {
+		b.state = StateHalfOpen
+		b.probes = 0
+		b.succeeded = 0
+	}
+}
@@ -0,0 +1,144 @@
+package examples
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var errDependency = errors.New("dependency failed")
+
+func failing() error { return errDependency }
+
+func succeeding() error { return nil }
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	b := NewCircuitBreaker(3, time.Minute, NewFakeClock(time.Now()))
+
+	for i := 0; i < 3; i++ {
+		if err := b.Execute(failing); !errors.Is(err, errDependency) {
+			t.Fatalf("call %d: expected dependency error, got %v", i, err)
+		}
+	}
+
+	if b.State() != StateOpen {
+		t.Fatalf("expected Open, got %v", b.State())
+	}
+	called := false
+	err := b.Execute(func() error { called = true; return nil })
+	if !errors.Is(err, ErrCircuitOpen) || called {
+		t.Errorf("expected fast ErrCircuitOpen without calling fn, got %v (called=%v)", err, called)
+	}
+}
+
+func TestCircuitBreakerSuccessResetsFailures(t *testing.T) {
+	b := NewCircuitBreaker(2, time.Minute, nil)
+
+	_ = b.Execute(failing)
+	_ = b.Execute(succeeding)
+	_ = b.Execute(failing)
+
+	if b.State() != StateClosed {
+		t.Errorf("expected non-consecutive failures to keep breaker closed, got %v", b.State())
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbe(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	b := NewCircuitBreaker(1, 10*time.Second, clock)
+	_ = b.Execute(failing)
+
+	clock.Advance(10 * time.Second)
+	if b.State() != StateHalfOpen {
+		t.Fatalf("expected HalfOpen after cooldown, got %v", b.State())
+	}
+
+	// Only one probe is admitted at a time.
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected probe to be allowed, got %v", err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected second probe to be rejected, got %v", err)
+	}
+
+	b.Record(errDependency)
+	if b.State() != StateOpen {
+		t.Fatalf("expected failed probe to reopen, got %v", b.State())
+	}
+
+	clock.Advance(10 * time.Second)
+	if err := b.Execute(succeeding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.State() != StateClosed {
+		t.Errorf("expected successful probe to close, got %v", b.State())
+	}
+}
+
+func TestBreakerStateString(t *testing.T) {
+	for state, want := range map[BreakerState]string{StateClosed: "Closed", StateOpen: "Open", StateHalfOpen: "HalfOpen"} {
+		if got := state.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbeLimit(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	b := NewCircuitBreaker(1, 10*time.Second, clock).WithHalfOpenLimits(2, 3)
+	_ = b.Execute(failing)
+	clock.Advance(10 * time.Second)
+
+	// Two probes may be in flight; the third is rejected.
+	for i := 0; i < 2; i++ {
+		if err := b.Allow(); err != nil {
+			t.Fatalf("probe %d: expected to be allowed, got %v", i, err)
+		}
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected probe beyond the limit to be rejected, got %v", err)
+	}
+
+	// Finishing a probe frees a slot, but the breaker stays half-open until
+	// three probes have succeeded.
+	b.Record(nil)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected freed probe slot to be reusable, got %v", err)
+	}
+	b.Record(nil)
+	if b.State() != StateHalfOpen {
+		t.Fatalf("expected HalfOpen after 2 of 3 successes, got %v", b.State())
+	}
+	b.Record(nil)
+	if b.State() != StateClosed {
+		t.Fatalf("expected Closed after 3 successes, got %v", b.State())
+	}
+}
+
+func TestCircuitBreakerHalfOpenFailureResetsProgress(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	b := NewCircuitBreaker(1, 10*time.Second, clock).WithHalfOpenLimits(1, 2)
+	_ = b.Execute(failing)
+	clock.Advance(10 * time.Second)
+
+	if err := b.Execute(succeeding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.Execute(failing); !errors.Is(err, errDependency) {
+		t.Fatalf("expected dependency error, got %v", err)
+	}
+	if b.State() != StateOpen {
+		t.Fatalf("expected failed probe to reopen, got %v", b.State())
+	}
+
+	// The earlier success does not carry over to the next half-open window.
+	clock.Advance(10 * time.Second)
+	_ = b.Execute(succeeding)
+	if b.State() != StateHalfOpen {
+		t.Errorf("expected HalfOpen after 1 of 2 successes, got %v", b.State())
+	}
+	_ = b.Execute(succeeding)
+	if b.State() != StateClosed {
+		t.Errorf("expected Closed after 2 successes, got %v", b.State())
+	}
+}
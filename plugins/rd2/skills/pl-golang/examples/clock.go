@@ -0,0 +1,179 @@
+package examples
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts time so time-dependent helpers can be tested without
+// sleeping.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is the subset of *time.Timer used through a Clock.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(d time.Duration) Timer { return realTimer{time.NewTimer(d)} }
+
+type realTimer struct{ t *time.Timer }
+
+func (t realTimer) C() <-chan time.Time        { return t.t.C }
+func (t realTimer) Stop() bool                 { return t.t.Stop() }
+func (t realTimer) Reset(d time.Duration) bool { return t.t.Reset(d) }
+
+// Ticker is the subset of *time.Ticker used through a Clock.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker { return realTicker{time.NewTicker(d)} }
+
+type realTicker struct{ t *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.t.C }
+func (t realTicker) Stop()               { t.t.Stop() }
+
+// RealClock is the Clock backed by the time package.
+var RealClock Clock = realClock{}
+
+// FakeClock is a manually advanced Clock for tests. Timers and tickers fire
+// when Advance moves the clock past their deadline; like real tickers, a fake
+// ticker drops ticks its reader is not ready for.
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	pending []*fakeTimer
+}
+
+// NewFakeClock returns a FakeClock starting at start.
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the fake current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer returns a timer that fires once the clock reaches Now()+d.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{clock: c, ch: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// NewTicker returns a ticker that fires every d of fake time.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("examples: non-positive interval for NewTicker")
+	}
+	t := &fakeTimer{clock: c, ch: make(chan time.Time, 1), period: d}
+	t.Reset(d)
+	return fakeTicker{t}
+}
+
+// Advance moves the fake time forward by d, firing every timer and ticker
+// that becomes due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+
+	kept := c.pending[:0]
+	for _, t := range c.pending {
+		if t.when.After(c.now) {
+			kept = append(kept, t)
+			continue
+		}
+		select {
+		case t.ch <- c.now:
+		default:
+		}
+		if t.period > 0 {
+			for !t.when.After(c.now) {
+				t.when = t.when.Add(t.period)
+			}
+			kept = append(kept, t)
+		}
+	}
+	c.pending = kept
+}
+
+// Pending returns the number of timers that have not fired or been stopped,
+// plus the number of running tickers.
+func (c *FakeClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.pending)
+}
+
+// BlockUntil waits until at least n timers are pending, so a test can be
+// sure the code under test is waiting before it calls Advance.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.pending) < n {
+		c.cond.Wait()
+	}
+}
+
+// fakeTimer implements both Timer and Ticker; a non-zero period makes it
+// re-arm after firing.
+type fakeTimer struct {
+	clock  *FakeClock
+	ch     chan time.Time
+	when   time.Time
+	period time.Duration
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.remove(t)
+}
+
+// fakeTicker adapts fakeTimer to the Ticker interface, whose Stop returns
+// nothing.
+type fakeTicker struct{ *fakeTimer }
+
+func (t fakeTicker) Stop() { t.fakeTimer.Stop() }
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	active := c.remove(t)
+	t.when = c.now.Add(d)
+	c.pending = append(c.pending, t)
+	c.cond.Broadcast()
+	return active
+}
+
+func (c *FakeClock) remove(t *fakeTimer) bool {
+	for i, p := range c.pending {
+		if p == t {
+			c.pending = append(c.pending[:i], c.pending[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package examples
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClockAdvance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	if !c.Now().Equal(start) {
+		t.Fatalf("expected %v, got %v", start, c.Now())
+	}
+
+	c.Advance(90 * time.Second)
+	if want := start.Add(90 * time.Second); !c.Now().Equal(want) {
+		t.Errorf("expected %v, got %v", want, c.Now())
+	}
+}
+
+func TestFakeClockTimer(t *testing.T) {
+	c := NewFakeClock(time.Unix(0, 0))
+	timer := c.NewTimer(time.Second)
+
+	c.Advance(999 * time.Millisecond)
+	select {
+	case <-timer.C():
+		t.Fatal("timer fired early")
+	default:
+	}
+
+	c.Advance(time.Millisecond)
+	select {
+	case <-timer.C():
+	default:
+		t.Fatal("timer did not fire at its deadline")
+	}
+	if c.Pending() != 0 {
+		t.Errorf("expected no pending timers, got %d", c.Pending())
+	}
+}
+
+func TestFakeClockTimerStopAndReset(t *testing.T) {
+	c := NewFakeClock(time.Unix(0, 0))
+	timer := c.NewTimer(time.Second)
+
+	if !timer.Stop() {
+		t.Error("expected Stop to report an active timer")
+	}
+	if timer.Stop() {
+		t.Error("expected second Stop to report an inactive timer")
+	}
+
+	timer.Reset(2 * time.Second)
+	c.Advance(2 * time.Second)
+	select {
+	case <-timer.C():
+	default:
+		t.Fatal("reset timer did not fire")
+	}
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	c := NewFakeClock(time.Unix(0, 0))
+	ticker := c.NewTicker(10 * time.Second)
+
+	for i := 0; i < 3; i++ {
+		c.Advance(10 * time.Second)
+		select {
+		case <-ticker.C():
+		default:
+			t.Fatalf("tick %d did not fire", i)
+		}
+	}
+
+	// Unread ticks are dropped rather than queued.
+	c.Advance(30 * time.Second)
+	<-ticker.C()
+	select {
+	case <-ticker.C():
+		t.Error("expected missed ticks to be dropped")
+	default:
+	}
+
+	ticker.Stop()
+	if c.Pending() != 0 {
+		t.Errorf("expected stopped ticker to be removed, %d pending", c.Pending())
+	}
+}
@@ -0,0 +1,78 @@
+package examples
+
+import (
+	"context"
+	"sync"
+)
+
+// Coalescer deduplicates concurrent fetches of the same key: the first caller
+// starts the fetch and later callers for that key wait for its result. It is
+// single-flight with per-caller cancellation: each caller stops waiting when
+// its own ctx is done, while the shared fetch keeps running for the others.
+// Only when every waiter has given up is the fetch's context canceled.
+//
+// Results are not cached; once a fetch completes, the next call starts a new
+// one. Combine with Memoize or TTLCache for caching, as CoalescingCache does.
+type Coalescer[K comparable, V any] struct {
+	fetch func(context.Context, K) (V, error)
+
+	mu    sync.Mutex
+	calls map[K]*coalescedCall[V]
+}
+
+type coalescedCall[V any] struct {
+	done    chan struct{}
+	val     V
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// NewCoalescer returns a Coalescer around fetch.
+func NewCoalescer[K comparable, V any](fetch func(context.Context, K) (V, error)) *Coalescer[K, V] {
+	return &Coalescer[K, V]{fetch: fetch, calls: make(map[K]*coalescedCall[V])}
+}
+
+// Do returns the result of the in-flight fetch for key, starting one if
+// needed. The fetch context carries the starting caller's values but not its
+// cancellation.
+func (c *Coalescer[K, V]) Do(ctx context.Context, key K) (V, error) {
+	c.mu.Lock()
+	call, ok := c.calls[key]
+	if !ok {
+		fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &coalescedCall[V]{done: make(chan struct{}), cancel: cancel}
+		c.calls[key] = call
+		go c.run(fetchCtx, key, call)
+	}
+	call.waiters++
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.val, call.err
+	case <-ctx.Done():
+		c.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 && c.calls[key] == call {
+			// Nobody is left to use the result.
+			delete(c.calls, key)
+			call.cancel()
+		}
+		c.mu.Unlock()
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (c *Coalescer[K, V]) run(ctx context.Context, key K, call *coalescedCall[V]) {
+	defer call.cancel()
+	call.val, call.err = c.fetch(ctx, key)
+
+	c.mu.Lock()
+	if c.calls[key] == call {
+		delete(c.calls, key)
+	}
+	c.mu.Unlock()
+	close(call.done)
+}
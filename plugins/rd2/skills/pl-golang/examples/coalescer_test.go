@@ -0,0 +1,121 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalescerSharesOneFetch(t *testing.T) {
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	c := NewCoalescer(func(ctx context.Context, id int) (string, error) {
+		fetches.Add(1)
+		<-release
+		return "user-1", nil
+	})
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make(chan string, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.Do(context.Background(), 1)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results <- v
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("expected 1 fetch, got %d", n)
+	}
+	for v := range results {
+		if v != "user-1" {
+			t.Errorf("expected user-1, got %q", v)
+		}
+	}
+}
+
+func TestCoalescerCallerCancelDoesNotAbortOthers(t *testing.T) {
+	release := make(chan struct{})
+	var fetchErr atomic.Value
+	c := NewCoalescer(func(ctx context.Context, id int) (int, error) {
+		select {
+		case <-release:
+			return id * 10, nil
+		case <-ctx.Done():
+			fetchErr.Store(ctx.Err())
+			return 0, ctx.Err()
+		}
+	})
+
+	patient := make(chan error, 1)
+	go func() {
+		v, err := c.Do(context.Background(), 4)
+		if err == nil && v != 40 {
+			err = errors.New("wrong value")
+		}
+		patient <- err
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.Do(ctx, 4); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+
+	close(release)
+	if err := <-patient; err != nil {
+		t.Fatalf("expected the other caller to get the result, got %v", err)
+	}
+	if err := fetchErr.Load(); err != nil {
+		t.Errorf("shared fetch was canceled: %v", err)
+	}
+}
+
+func TestCoalescerCancelsFetchWhenAllWaitersLeave(t *testing.T) {
+	canceled := make(chan struct{})
+	c := NewCoalescer(func(ctx context.Context, id int) (int, error) {
+		<-ctx.Done()
+		close(canceled)
+		return 0, ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.Do(ctx, 1)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected Canceled, got %v", err)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("expected abandoned fetch to be canceled")
+	}
+}
+
+func TestCoalescerPropagatesError(t *testing.T) {
+	errUpstream := errors.New("upstream down")
+	c := NewCoalescer(func(context.Context, string) (int, error) { return 0, errUpstream })
+	if _, err := c.Do(context.Background(), "k"); !errors.Is(err, errUpstream) {
+		t.Errorf("expected upstream error, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// CoalescingCache fronts a slow backend: concurrent misses for a key share
+// one fetch through a Coalescer, and results are kept in a TTLCache.
+//
+// With a positive negativeTTL, failed fetches (a not-found error, say) are
+// cached too, for that shorter TTL, so a flood of requests for a missing key
+// reaches the backend once per negativeTTL instead of once per request.
+// Context errors are never cached since they belong to the callers, not the
+// key.
+type CoalescingCache[K comparable, V any] struct {
+	coalescer   *Coalescer[K, V]
+	cache       *TTLCache[K, cachedResult[V]]
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+type cachedResult[V any] struct {
+	val V
+	err error
+}
+
+// NewCoalescingCache returns a cache around fetch that keeps values for ttl
+// and errors for negativeTTL; a TTL <= 0 disables caching of that kind.
+// Expired entries are swept every sweepInterval, which must be positive as
+// for NewTTLCache. A nil clock means RealClock. Call Close to stop the
+// sweeper.
+func NewCoalescingCache[K comparable, V any](fetch func(context.Context, K) (V, error), ttl, negativeTTL, sweepInterval time.Duration, clock Clock) *CoalescingCache[K, V] {
+	c := &CoalescingCache[K, V]{
+		cache:       NewTTLCache[K, cachedResult[V]](sweepInterval, clock),
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+	}
+	c.coalescer = NewCoalescer(func(ctx context.Context, key K) (V, error) {
+		v, err := fetch(ctx, key)
+		switch {
+		case err == nil && c.ttl > 0:
+			c.cache.Set(key, cachedResult[V]{val: v}, c.ttl)
+		case err != nil && c.negativeTTL > 0 && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded):
+			c.cache.Set(key, cachedResult[V]{err: err}, c.negativeTTL)
+		}
+		return v, err
+	})
+	return c
+}
+
+// Get returns the cached result for key, fetching it on a miss.
+func (c *CoalescingCache[K, V]) Get(ctx context.Context, key K) (V, error) {
+	if r, ok := c.cache.Get(key); ok {
+		return r.val, r.err
+	}
+	return c.coalescer.Do(ctx, key)
+}
+
+// Close stops the underlying TTLCache's sweeper.
+func (c *CoalescingCache[K, V]) Close() {
+	c.cache.Close()
+}
@@ -0,0 +1,96 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalescingCacheNegativeCaching(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	var fetches atomic.Int32
+	c := NewCoalescingCache(func(ctx context.Context, id int) (string, error) {
+		fetches.Add(1)
+		return "", ErrNotFound
+	}, time.Minute, 5*time.Second, time.Minute, clock)
+	defer c.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Get(context.Background(), 42); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("expected not-found to be served from cache, got %d fetches", n)
+	}
+
+	// The negative entry expires on its own, shorter TTL.
+	clock.Advance(5 * time.Second)
+	if _, err := c.Get(context.Background(), 42); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("expected a re-fetch after the negative TTL, got %d fetches", n)
+	}
+}
+
+func TestCoalescingCachePositiveTTL(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	var fetches atomic.Int32
+	c := NewCoalescingCache(func(ctx context.Context, id int) (int, error) {
+		return int(fetches.Add(1)), nil
+	}, time.Minute, 5*time.Second, time.Minute, clock)
+	defer c.Close()
+
+	get := func() int {
+		t.Helper()
+		v, err := c.Get(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return v
+	}
+
+	if v := get(); v != 1 {
+		t.Errorf("expected first fetch, got %d", v)
+	}
+	clock.Advance(30 * time.Second)
+	if v := get(); v != 1 {
+		t.Errorf("expected cached value within TTL, got %d", v)
+	}
+	clock.Advance(30 * time.Second)
+	if v := get(); v != 2 {
+		t.Errorf("expected re-fetch after TTL, got %d", v)
+	}
+}
+
+func TestCoalescingCacheNegativeCachingDisabled(t *testing.T) {
+	var fetches atomic.Int32
+	c := NewCoalescingCache(func(ctx context.Context, id int) (int, error) {
+		fetches.Add(1)
+		return 0, ErrNotFound
+	}, time.Minute, 0, time.Minute, NewFakeClock(time.Now()))
+	defer c.Close()
+
+	c.Get(context.Background(), 1)
+	c.Get(context.Background(), 1)
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("expected errors not to be cached, got %d fetches", n)
+	}
+}
+
+func TestCoalescingCacheZeroTTL(t *testing.T) {
+	var fetches atomic.Int32
+	c := NewCoalescingCache(func(ctx context.Context, id int) (int, error) {
+		return int(fetches.Add(1)), nil
+	}, 0, 0, time.Minute, NewFakeClock(time.Now()))
+	defer c.Close()
+
+	c.Get(context.Background(), 1)
+	c.Get(context.Background(), 1)
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("expected a zero TTL to disable caching, got %d fetches", n)
+	}
+}
@@ -0,0 +1,59 @@
+package examples
+
+import "sync"
+
+// ConcurrentMap is a type-safe wrapper around sync.Map: no any in the API and
+// no type assertions at call sites. V may be any type; compare-and-swap is
+// the package-level CompareAndSwap, which needs a comparable V. The zero
+// value is an empty map ready to use.
+type ConcurrentMap[K comparable, V any] struct {
+	m sync.Map
+}
+
+// Load returns the value stored for key, if any.
+func (c *ConcurrentMap[K, V]) Load(key K) (V, bool) {
+	v, ok := c.m.Load(key)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	val, _ := v.(V) // a nil interface V is stored as a nil any
+	return val, true
+}
+
+// Store sets the value for key.
+func (c *ConcurrentMap[K, V]) Store(key K, value V) {
+	c.m.Store(key, value)
+}
+
+// LoadOrStore returns the existing value for key if present. Otherwise it
+// stores and returns value. loaded reports whether the value was already
+// there.
+func (c *ConcurrentMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	v, loaded := c.m.LoadOrStore(key, value)
+	actual, _ = v.(V)
+	return actual, loaded
+}
+
+// Delete removes key.
+func (c *ConcurrentMap[K, V]) Delete(key K) {
+	c.m.Delete(key)
+}
+
+// CompareAndSwap stores newValue for key in c only if the current value
+// equals old, reporting whether it did. It is a function rather than a
+// method so that only maps with comparable values can use it; sync.Map
+// would panic at run time on anything else.
+func CompareAndSwap[K, V comparable](c *ConcurrentMap[K, V], key K, old, newValue V) bool {
+	return c.m.CompareAndSwap(key, old, newValue)
+}
+
+// Range calls fn for each entry until fn returns false. As with sync.Map, it
+// does not correspond to a consistent snapshot if the map is modified
+// concurrently.
+func (c *ConcurrentMap[K, V]) Range(fn func(key K, value V) bool) {
+	c.m.Range(func(k, v any) bool {
+		val, _ := v.(V)
+		return fn(k.(K), val)
+	})
+}
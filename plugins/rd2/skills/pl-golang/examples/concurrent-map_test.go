@@ -0,0 +1,147 @@
+package examples
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentMapLoadOrStore(t *testing.T) {
+	var m ConcurrentMap[string, int]
+
+	if v, loaded := m.LoadOrStore("a", 1); loaded || v != 1 {
+		t.Errorf("expected (1, false) on first store, got (%d, %v)", v, loaded)
+	}
+	if v, loaded := m.LoadOrStore("a", 2); !loaded || v != 1 {
+		t.Errorf("expected existing (1, true), got (%d, %v)", v, loaded)
+	}
+	if v, ok := m.Load("a"); !ok || v != 1 {
+		t.Errorf("expected stored 1, got (%d, %v)", v, ok)
+	}
+
+	m.Delete("a")
+	if _, ok := m.Load("a"); ok {
+		t.Error("expected key to be deleted")
+	}
+}
+
+func TestConcurrentMapCompareAndSwap(t *testing.T) {
+	var m ConcurrentMap[string, string]
+	m.Store("state", "pending")
+
+	tests := []struct {
+		name    string
+		old     string
+		new     string
+		swapped bool
+		final   string
+	}{
+		{"mismatched old value", "done", "failed", false, "pending"},
+		{"matching old value", "pending", "running", true, "running"},
+		{"stale old value", "pending", "done", false, "running"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareAndSwap(&m, "state", tt.old, tt.new); got != tt.swapped {
+				t.Errorf("expected swapped=%v, got %v", tt.swapped, got)
+			}
+			if v, _ := m.Load("state"); v != tt.final {
+				t.Errorf("expected %q, got %q", tt.final, v)
+			}
+		})
+	}
+
+	if CompareAndSwap(&m, "missing", "", "x") {
+		t.Error("expected CompareAndSwap on a missing key to fail")
+	}
+}
+
+func TestConcurrentMapRange(t *testing.T) {
+	var m ConcurrentMap[int, int]
+	for i := 0; i < 5; i++ {
+		m.Store(i, i*i)
+	}
+
+	sum := 0
+	m.Range(func(k, v int) bool {
+		if v != k*k {
+			t.Errorf("key %d: expected %d, got %d", k, k*k, v)
+		}
+		sum += v
+		return true
+	})
+	if sum != 0+1+4+9+16 {
+		t.Errorf("expected sum 30, got %d", sum)
+	}
+
+	visited := 0
+	m.Range(func(int, int) bool {
+		visited++
+		return false
+	})
+	if visited != 1 {
+		t.Errorf("expected Range to stop after 1, visited %d", visited)
+	}
+}
+
+func TestConcurrentMapConcurrentAccess(t *testing.T) {
+	var m ConcurrentMap[int, int]
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m.LoadOrStore(i, 0)
+				for {
+					v, _ := m.Load(i)
+					if CompareAndSwap(&m, i, v, v+1) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	m.Range(func(k, v int) bool {
+		if v != 8 {
+			t.Errorf("key %d: expected 8 increments, got %d", k, v)
+		}
+		return true
+	})
+}
+
+func TestConcurrentMapNonComparableValues(t *testing.T) {
+	var m ConcurrentMap[string, []string]
+	m.Store("admins", []string{"alice"})
+
+	tags, loaded := m.LoadOrStore("admins", nil)
+	if !loaded || !slices.Equal(tags, []string{"alice"}) {
+		t.Errorf("expected existing [alice], got %v (loaded=%v)", tags, loaded)
+	}
+	m.Store("admins", append(tags, "bob"))
+	if v, _ := m.Load("admins"); !slices.Equal(v, []string{"alice", "bob"}) {
+		t.Errorf("expected [alice bob], got %v", v)
+	}
+}
+
+func TestConcurrentMapNilInterfaceValue(t *testing.T) {
+	var m ConcurrentMap[string, error]
+	m.Store("ok", nil)
+
+	if err, ok := m.Load("ok"); !ok || err != nil {
+		t.Errorf("expected stored nil error, got %v (ok=%v)", err, ok)
+	}
+	if err, loaded := m.LoadOrStore("ok", errors.New("unused")); !loaded || err != nil {
+		t.Errorf("expected existing nil error, got %v (loaded=%v)", err, loaded)
+	}
+	m.Range(func(k string, err error) bool {
+		if err != nil {
+			t.Errorf("key %s: expected nil error, got %v", k, err)
+		}
+		return true
+	})
+}
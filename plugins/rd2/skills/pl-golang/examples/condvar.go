@@ -0,0 +1,79 @@
+package examples
+
+import (
+	"context"
+	"sync"
+)
+
+// CondVar is a condition variable whose Wait can be abandoned through a
+// context, which sync.Cond does not allow. As with sync.Cond, L is held when
+// calling Wait and is held again when Wait returns, so callers re-check their
+// condition in a loop.
+type CondVar struct {
+	L sync.Locker
+
+	mu      sync.Mutex
+	waiters []chan struct{}
+}
+
+// NewCondVar returns a CondVar guarding state protected by l.
+func NewCondVar(l sync.Locker) *CondVar {
+	return &CondVar{L: l}
+}
+
+// Wait atomically unlocks L and suspends until Signal, Broadcast, or ctx is
+// done, then relocks L. It returns ctx.Err() only if the waiter was not
+// already woken by a signal.
+func (c *CondVar) Wait(ctx context.Context) error {
+	ch := make(chan struct{})
+	c.mu.Lock()
+	c.waiters = append(c.waiters, ch)
+	c.mu.Unlock()
+
+	c.L.Unlock()
+	defer c.L.Lock()
+
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		if c.remove(ch) {
+			return ctx.Err()
+		}
+		// A signal raced with cancellation and was already delivered to us.
+		return nil
+	}
+}
+
+// Signal wakes the longest-waiting goroutine, if any.
+func (c *CondVar) Signal() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.waiters) == 0 {
+		return
+	}
+	close(c.waiters[0])
+	c.waiters = c.waiters[1:]
+}
+
+// Broadcast wakes all waiting goroutines.
+func (c *CondVar) Broadcast() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, ch := range c.waiters {
+		close(ch)
+	}
+	c.waiters = nil
+}
+
+func (c *CondVar) remove(ch chan struct{}) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, w := range c.waiters {
+		if w == ch {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCondVarBroadcast(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	var mu sync.Mutex
+	cv := NewCondVar(&mu)
+	ready := false
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mu.Lock()
+			defer mu.Unlock()
+			for !ready {
+				if err := cv.Wait(context.Background()); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	ready = true
+	cv.Broadcast()
+	mu.Unlock()
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("unexpected error: %v", err)
+	}
+	assertNoGoroutineLeak(t, baseline)
+}
+
+func TestCondVarSignalWakesOne(t *testing.T) {
+	var mu sync.Mutex
+	cv := NewCondVar(&mu)
+
+	woken := make(chan struct{}, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			mu.Lock()
+			defer mu.Unlock()
+			if err := cv.Wait(context.Background()); err == nil {
+				woken <- struct{}{}
+			}
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	cv.Signal()
+
+	select {
+	case <-woken:
+	case <-time.After(time.Second):
+		t.Fatal("expected one waiter to wake")
+	}
+	select {
+	case <-woken:
+		t.Fatal("expected only one waiter to wake")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cv.Broadcast()
+	<-woken
+}
+
+func TestCondVarContextCancel(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	var mu sync.Mutex
+	cv := NewCondVar(&mu)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	mu.Lock()
+	err := cv.Wait(ctx)
+	mu.Unlock()
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if len(cv.waiters) != 0 {
+		t.Errorf("expected canceled waiter to be removed, have %d", len(cv.waiters))
+	}
+	assertNoGoroutineLeak(t, baseline)
+}
@@ -0,0 +1,117 @@
+package examples
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Load builds a T from environment variables described by struct tags:
+//
+//	type Config struct {
+//		Port    int           `env:"PORT" default:"8080"`
+//		DBURL   string        `env:"DATABASE_URL" required:"true"`
+//		Timeout time.Duration `env:"TIMEOUT" default:"5s"`
+//		Debug   bool          `env:"DEBUG"`
+//	}
+//
+//	cfg, err := Load[Config]()
+//
+// An empty variable counts as unset. Missing required variables and values
+// that fail to parse are all reported together, joined with errors.Join.
+// Supported field kinds are strings, bools, integers, floats, and
+// time.Duration.
+func Load[T any]() (T, error) {
+	var cfg T
+	v := reflect.ValueOf(&cfg).Elem()
+	if v.Kind() != reflect.Struct {
+		return cfg, fmt.Errorf("load config: %T is not a struct", cfg)
+	}
+
+	var errs []error
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name, ok := field.Tag.Lookup("env")
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		raw := Coalesce(os.Getenv(name), field.Tag.Get("default"))
+		if raw == "" {
+			if field.Tag.Get("required") == "true" {
+				errs = append(errs, fmt.Errorf("%s is required", name))
+			}
+			continue
+		}
+		if err := setField(v.Field(i), raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		var zero T
+		return zero, fmt.Errorf("load config: %w", errors.Join(errs...))
+	}
+	return cfg, nil
+}
+
+// UnmarshalWithDefaults decodes JSON data over a copy of defaults, so
+// fields absent from data keep their default values instead of becoming
+// zero. Nested objects merge the same way. The copy is shallow: maps and
+// slices in defaults are decoded into in place, so build defaults fresh for
+// each call if it has any.
+func UnmarshalWithDefaults[T any](data []byte, defaults T) (T, error) {
+	cfg := defaults
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		var zero T
+		return zero, fmt.Errorf("unmarshal config: %w", err)
+	}
+	return cfg, nil
+}
+
+func setField(f reflect.Value, raw string) error {
+	if f.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(d))
+		return nil
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", f.Type())
+	}
+	return nil
+}
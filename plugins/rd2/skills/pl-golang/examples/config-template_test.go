@@ -0,0 +1,120 @@
+package examples
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Port    int           `env:"TEST_PORT" default:"8080"`
+	DBURL   string        `env:"TEST_DATABASE_URL" required:"true"`
+	APIKey  string        `env:"TEST_API_KEY" required:"true"`
+	Timeout time.Duration `env:"TEST_TIMEOUT" default:"5s"`
+	Debug   bool          `env:"TEST_DEBUG"`
+	Ratio   float64       `env:"TEST_RATIO" default:"0.5"`
+	ignored string
+}
+
+func TestLoadFromEnv(t *testing.T) {
+	t.Setenv("TEST_PORT", "9090")
+	t.Setenv("TEST_DATABASE_URL", "postgres://localhost/app")
+	t.Setenv("TEST_API_KEY", "secret")
+	t.Setenv("TEST_TIMEOUT", "250ms")
+	t.Setenv("TEST_DEBUG", "true")
+
+	cfg, err := Load[testConfig]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Port != 9090 {
+		t.Errorf("expected Port 9090, got %d", cfg.Port)
+	}
+	if cfg.DBURL != "postgres://localhost/app" {
+		t.Errorf("unexpected DBURL %q", cfg.DBURL)
+	}
+	if cfg.Timeout != 250*time.Millisecond {
+		t.Errorf("expected Timeout 250ms, got %v", cfg.Timeout)
+	}
+	if !cfg.Debug {
+		t.Error("expected Debug true")
+	}
+}
+
+func TestLoadAppliesDefaults(t *testing.T) {
+	t.Setenv("TEST_DATABASE_URL", "db")
+	t.Setenv("TEST_API_KEY", "key")
+
+	cfg, err := Load[testConfig]()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Port != 8080 || cfg.Timeout != 5*time.Second || cfg.Ratio != 0.5 || cfg.Debug {
+		t.Errorf("expected defaults, got %+v", cfg)
+	}
+}
+
+func TestLoadReportsAllErrors(t *testing.T) {
+	t.Setenv("TEST_PORT", "not-a-number")
+
+	_, err := Load[testConfig]()
+	if err == nil {
+		t.Fatal("expected error for missing required vars")
+	}
+
+	for _, want := range []string{"TEST_DATABASE_URL is required", "TEST_API_KEY is required", "TEST_PORT"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %q, got: %s", want, err.Error())
+		}
+	}
+}
+
+func TestLoadRejectsNonStruct(t *testing.T) {
+	if _, err := Load[int](); err == nil {
+		t.Error("expected error for non-struct config type")
+	}
+}
+
+type jsonConfig struct {
+	Addr    string        `json:"addr"`
+	Timeout time.Duration `json:"timeout"`
+	Debug   bool          `json:"debug"`
+	DB      struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	} `json:"db"`
+}
+
+func TestUnmarshalWithDefaults(t *testing.T) {
+	defaults := jsonConfig{Addr: ":8080", Timeout: 5 * time.Second}
+	defaults.DB.Host, defaults.DB.Port = "localhost", 5432
+
+	cfg, err := UnmarshalWithDefaults([]byte(`{"addr": ":9090", "debug": true, "db": {"host": "db.internal"}}`), defaults)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := defaults
+	want.Addr, want.Debug, want.DB.Host = ":9090", true, "db.internal"
+	if cfg != want {
+		t.Errorf("expected %+v, got %+v", want, cfg)
+	}
+	if defaults.Addr != ":8080" || defaults.DB.Host != "localhost" {
+		t.Errorf("expected defaults untouched, got %+v", defaults)
+	}
+}
+
+func TestUnmarshalWithDefaultsMalformed(t *testing.T) {
+	cfg, err := UnmarshalWithDefaults([]byte(`{"addr": `), jsonConfig{Addr: ":8080"})
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("expected a JSON syntax error, got %v", err)
+	}
+	if cfg != (jsonConfig{}) {
+		t.Errorf("expected zero config on error, got %+v", cfg)
+	}
+}
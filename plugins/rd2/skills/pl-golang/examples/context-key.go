@@ -0,0 +1,48 @@
+package examples
+
+import "context"
+
+// ContextKey is a typed context key. Each key returned by NewContextKey is
+// distinct, even for equal names, and Value needs no type assertion at the
+// call site.
+type ContextKey[T any] struct {
+	id   *struct{ _ byte }
+	name string
+}
+
+// NewContextKey returns a new key; name is only used for debugging.
+func NewContextKey[T any](name string) ContextKey[T] {
+	return ContextKey[T]{id: new(struct{ _ byte }), name: name}
+}
+
+// String returns the key's name.
+func (k ContextKey[T]) String() string {
+	return k.name
+}
+
+// WithValue returns a child of ctx carrying v under k.
+func (k ContextKey[T]) WithValue(ctx context.Context, v T) context.Context {
+	return context.WithValue(ctx, k, v)
+}
+
+// Value returns the value stored under k, if any.
+func (k ContextKey[T]) Value(ctx context.Context) (T, bool) {
+	v, ok := ctx.Value(k).(T)
+	return v, ok
+}
+
+// PushValue scopes an override of key to the returned child context, and
+// returns end to close that scope. Nothing needs restoring: contexts are
+// immutable, so ctx keeps its own value throughout. end cancels the child
+// instead, so any work still holding the overridden context is told to stop
+// rather than outliving the override.
+//
+// Example:
+//
+//	scoped, end := PushValue(ctx, timeoutKey, 30*time.Second)
+//	runSlowMigration(scoped)
+//	end()
+//	// continue with ctx, which still carries the original timeout
+func PushValue[T any](ctx context.Context, key ContextKey[T], v T) (scoped context.Context, end func()) {
+	return context.WithCancel(key.WithValue(ctx, v))
+}
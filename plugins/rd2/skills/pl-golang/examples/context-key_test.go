@@ -0,0 +1,93 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestContextKeyRoundTrip(t *testing.T) {
+	key := NewContextKey[int]("retries")
+	ctx := key.WithValue(context.Background(), 3)
+
+	if v, ok := key.Value(ctx); !ok || v != 3 {
+		t.Errorf("expected (3, true), got (%d, %v)", v, ok)
+	}
+	if _, ok := key.Value(context.Background()); ok {
+		t.Error("expected missing value on a bare context")
+	}
+	if key.String() != "retries" {
+		t.Errorf("expected name retries, got %q", key.String())
+	}
+}
+
+func TestContextKeysAreDistinct(t *testing.T) {
+	a := NewContextKey[string]("user")
+	b := NewContextKey[string]("user")
+
+	ctx := a.WithValue(context.Background(), "alice")
+	if _, ok := b.Value(ctx); ok {
+		t.Error("expected keys with the same name to be distinct")
+	}
+}
+
+func TestPushValueScopedOverride(t *testing.T) {
+	timeoutKey := NewContextKey[time.Duration]("timeout")
+	ctx := timeoutKey.WithValue(context.Background(), 5*time.Second)
+
+	scoped, end := PushValue(ctx, timeoutKey, 30*time.Second)
+
+	// A worker that holds on to the scoped context sees the override and is
+	// stopped when the scope ends.
+	seen := make(chan time.Duration, 1)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		v, _ := timeoutKey.Value(scoped)
+		seen <- v
+		<-scoped.Done()
+	}()
+
+	if v := <-seen; v != 30*time.Second {
+		t.Errorf("expected the worker to see the 30s override, got %v", v)
+	}
+	end()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("expected end to stop the worker holding the scoped context")
+	}
+	if v, _ := timeoutKey.Value(ctx); v != 5*time.Second {
+		t.Errorf("expected the original 5s outside the scope after end, got %v", v)
+	}
+	if !errors.Is(scoped.Err(), context.Canceled) {
+		t.Errorf("expected the scoped context to be canceled, got %v", scoped.Err())
+	}
+	if ctx.Err() != nil {
+		t.Error("expected the parent context to be unaffected by end")
+	}
+}
+
+func TestPushValueNested(t *testing.T) {
+	key := NewContextKey[string]("role")
+	base := key.WithValue(context.Background(), "viewer")
+
+	outer, endOuter := PushValue(base, key, "editor")
+	defer endOuter()
+	inner, endInner := PushValue(outer, key, "admin")
+
+	if v, _ := key.Value(inner); v != "admin" {
+		t.Errorf("expected admin in inner scope, got %q", v)
+	}
+	if v, _ := key.Value(outer); v != "editor" {
+		t.Errorf("expected editor in outer scope, got %q", v)
+	}
+	endInner()
+	if inner.Err() == nil {
+		t.Error("expected inner scope to be done after endInner")
+	}
+	if outer.Err() != nil {
+		t.Error("expected outer scope to stay active after endInner")
+	}
+}
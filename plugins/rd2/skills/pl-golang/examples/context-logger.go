@@ -0,0 +1,41 @@
+package examples
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+var loggerKey = NewContextKey[*slog.Logger]("logger")
+
+// WithLogger returns a child context carrying l.
+func WithLogger(ctx context.Context, l *slog.Logger) context.Context {
+	return loggerKey.WithValue(ctx, l)
+}
+
+// LoggerFrom returns the logger stored by WithLogger, or slog.Default if
+// there is none, so callers never need a nil check.
+func LoggerFrom(ctx context.Context) *slog.Logger {
+	if l, ok := loggerKey.Value(ctx); ok && l != nil {
+		return l
+	}
+	return slog.Default()
+}
+
+// ContextLogger installs a request-scoped logger derived from base, tagged
+// with the request ID (see RequestIDMiddleware), method, and path. Handlers
+// fetch it with LoggerFrom(r.Context()) so every line they log can be tied
+// back to the request.
+func ContextLogger(base *slog.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, _ := RequestID(r.Context())
+			l := base.With(
+				slog.String("request_id", id),
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+			)
+			next.ServeHTTP(w, r.WithContext(WithLogger(r.Context(), l)))
+		})
+	}
+}
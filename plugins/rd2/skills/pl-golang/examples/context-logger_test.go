@@ -0,0 +1,41 @@
+package examples
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLoggerFrom(t *testing.T) {
+	if LoggerFrom(context.Background()) != slog.Default() {
+		t.Error("expected slog.Default without a logger in context")
+	}
+
+	l := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	if LoggerFrom(WithLogger(context.Background(), l)) != l {
+		t.Error("expected the stored logger")
+	}
+}
+
+func TestContextLoggerTagsRequest(t *testing.T) {
+	var logs bytes.Buffer
+	base := slog.New(slog.NewTextHandler(&logs, nil))
+
+	handler := ContextLogger(base)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		LoggerFrom(r.Context()).InfoContext(r.Context(), "handled")
+	}))
+
+	req := httptest.NewRequest(http.MethodDelete, "/items/3", nil)
+	req = req.WithContext(WithRequestID(req.Context(), "req-5"))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	for _, want := range []string{"request_id=req-5", "method=DELETE", "path=/items/3", "msg=handled"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("expected %q in log line, got %q", want, logs.String())
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrIdleTimeout is returned by CopyWithTimeout when no data arrived within
+// the idle timeout.
+var ErrIdleTimeout = errors.New("idle timeout")
+
+// CopyWithTimeout copies src to dst like io.Copy, but gives up with
+// ErrIdleTimeout once idleTimeout passes without any bytes being read, and
+// with ctx.Err() when ctx is done. A slow but steady stream never times
+// out; a stalled one does. It returns the number of bytes written.
+//
+// An io.Reader cannot be interrupted, so a stalled Read is abandoned rather
+// than stopped: its goroutine exits once the Read returns. Close src, or set
+// a deadline if it is a net.Conn, to free it promptly.
+func CopyWithTimeout(ctx context.Context, dst io.Writer, src io.Reader, idleTimeout time.Duration) (int64, error) {
+	return copyWithTimeoutClock(ctx, RealClock, dst, src, idleTimeout)
+}
+
+type readResult struct {
+	n   int
+	err error
+}
+
+func copyWithTimeoutClock(ctx context.Context, clock Clock, dst io.Writer, src io.Reader, idleTimeout time.Duration) (int64, error) {
+	buf := make([]byte, 32*1024)
+	results := make(chan readResult)
+	next := make(chan struct{}) // hands buf back to the reader once written
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for {
+			n, err := src.Read(buf)
+			select {
+			case results <- readResult{n, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+			select {
+			case <-next:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	timer := clock.NewTimer(idleTimeout)
+	defer func() { timer.Stop() }()
+
+	var written int64
+	for {
+		select {
+		case r := <-results:
+			if r.n > 0 {
+				// Only time src: a slow dst must not count as idle.
+				timer.Stop()
+				nw, err := dst.Write(buf[:r.n])
+				written += int64(nw)
+				if err != nil {
+					return written, err
+				}
+				if nw != r.n {
+					return written, io.ErrShortWrite
+				}
+			}
+			if r.err == io.EOF {
+				return written, nil
+			}
+			if r.err != nil {
+				return written, r.err
+			}
+			next <- struct{}{}
+			if r.n > 0 {
+				timer = clock.NewTimer(idleTimeout)
+			}
+		case <-timer.C():
+			return written, ErrIdleTimeout
+		case <-ctx.Done():
+			return written, ctx.Err()
+		}
+	}
+}
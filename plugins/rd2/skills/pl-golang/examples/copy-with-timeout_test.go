@@ -0,0 +1,146 @@
+package examples
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// feedReader returns one chunk per value sent on feed and io.EOF once feed
+// is closed, so a test controls exactly when data flows.
+type feedReader struct {
+	feed chan string
+}
+
+func (r *feedReader) Read(p []byte) (int, error) {
+	chunk, ok := <-r.feed
+	if !ok {
+		return 0, io.EOF
+	}
+	return copy(p, chunk), nil
+}
+
+// signalWriter records writes and signals after each one.
+type signalWriter struct {
+	mu    sync.Mutex
+	buf   bytes.Buffer
+	wrote chan struct{}
+}
+
+func (w *signalWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(p)
+	w.wrote <- struct{}{}
+	return len(p), nil
+}
+
+type copyResult struct {
+	n   int64
+	err error
+}
+
+func startCopy(ctx context.Context, clock Clock, idle time.Duration) (*feedReader, *signalWriter, <-chan copyResult) {
+	src := &feedReader{feed: make(chan string)}
+	dst := &signalWriter{wrote: make(chan struct{}, 10)}
+	done := make(chan copyResult, 1)
+	go func() {
+		n, err := copyWithTimeoutClock(ctx, clock, dst, src, idle)
+		done <- copyResult{n, err}
+	}()
+	return src, dst, done
+}
+
+func awaitWrite(t *testing.T, w *signalWriter) {
+	t.Helper()
+	select {
+	case <-w.wrote:
+	case <-time.After(time.Second):
+		t.Fatal("expected a write")
+	}
+}
+
+func TestCopyWithTimeoutSteady(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	src, dst, done := startCopy(context.Background(), clock, 10*time.Second)
+
+	// Slow but steady: each chunk arrives inside the idle window, though the
+	// whole copy takes longer than it.
+	for _, chunk := range []string{"ab", "cd", "ef"} {
+		src.feed <- chunk
+		awaitWrite(t, dst)
+		clock.Advance(9 * time.Second)
+	}
+	close(src.feed)
+
+	r := <-done
+	if r.err != nil || r.n != 6 {
+		t.Fatalf("expected (6, nil), got (%d, %v)", r.n, r.err)
+	}
+	if got := dst.buf.String(); got != "abcdef" {
+		t.Errorf("expected abcdef, got %q", got)
+	}
+}
+
+func TestCopyWithTimeoutStalls(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	src, dst, done := startCopy(context.Background(), clock, 10*time.Second)
+	defer close(src.feed) // releases the abandoned Read
+
+	src.feed <- "hello"
+	awaitWrite(t, dst)
+	clock.Advance(10 * time.Second)
+
+	r := <-done
+	if !errors.Is(r.err, ErrIdleTimeout) || r.n != 5 {
+		t.Errorf("expected (5, ErrIdleTimeout), got (%d, %v)", r.n, r.err)
+	}
+}
+
+// slowWriter advances clock by d on every write, standing in for a
+// destination that takes longer than the idle timeout to accept data.
+type slowWriter struct {
+	clock *FakeClock
+	d     time.Duration
+	n     int
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	w.clock.Advance(w.d)
+	w.n += len(p)
+	return len(p), nil
+}
+
+func TestCopyWithTimeoutSlowWriter(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	dst := &slowWriter{clock: clock, d: 50 * time.Millisecond}
+
+	n, err := copyWithTimeoutClock(context.Background(), clock, dst, bytes.NewReader(make([]byte, 100_000)), 20*time.Millisecond)
+	if err != nil || n != 100_000 || dst.n != 100_000 {
+		t.Errorf("expected a slow writer not to trip the idle timeout, got (%d, %v)", n, err)
+	}
+}
+
+func TestCopyWithTimeoutCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	src, _, done := startCopy(ctx, NewFakeClock(time.Unix(0, 0)), time.Hour)
+	defer close(src.feed)
+
+	cancel()
+	r := <-done
+	if !errors.Is(r.err, context.Canceled) || r.n != 0 {
+		t.Errorf("expected (0, Canceled), got (%d, %v)", r.n, r.err)
+	}
+}
+
+func TestCopyWithTimeoutRealClock(t *testing.T) {
+	var dst bytes.Buffer
+	n, err := CopyWithTimeout(context.Background(), &dst, bytes.NewReader(make([]byte, 100_000)), time.Second)
+	if err != nil || n != 100_000 || dst.Len() != 100_000 {
+		t.Errorf("expected 100000 bytes copied, got (%d, %v), buffer %d", n, err, dst.Len())
+	}
+}
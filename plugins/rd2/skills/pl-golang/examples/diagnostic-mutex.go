@@ -0,0 +1,96 @@
+package examples
+
+import (
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// LockDiagnostic describes a Lock call that waited longer than the
+// configured threshold.
+type LockDiagnostic struct {
+	Waited      time.Duration // how long the waiter had waited when reported
+	HeldSince   time.Time     // when the current holder acquired the lock
+	HolderStack string        // stack of the holder at acquisition time
+}
+
+// DiagnosticMutex is a sync.Mutex that reports slow acquisitions together
+// with the stack of the goroutine holding the lock, to help track down
+// deadlocks and long critical sections.
+//
+// Diagnostics are off while Threshold is zero, in which case it behaves like
+// a plain mutex with no extra cost beyond a branch. Enable it from a flag or
+// environment variable in debugging builds; capturing stacks on every
+// acquisition is too expensive to leave on in production.
+type DiagnosticMutex struct {
+	Threshold  time.Duration        // 0 disables diagnostics
+	OnSlowLock func(LockDiagnostic) // nil logs through slog.Default
+
+	mu sync.Mutex
+
+	infoMu      sync.Mutex
+	heldSince   time.Time
+	holderStack []byte
+}
+
+// Lock acquires the mutex, reporting once if the wait exceeds Threshold.
+func (m *DiagnosticMutex) Lock() {
+	if m.Threshold <= 0 {
+		m.mu.Lock()
+		return
+	}
+	if m.mu.TryLock() {
+		m.recordHolder()
+		return
+	}
+
+	start := time.Now()
+	timer := time.AfterFunc(m.Threshold, func() { m.report(time.Since(start)) })
+	m.mu.Lock()
+	timer.Stop()
+	m.recordHolder()
+}
+
+// Unlock releases the mutex.
+func (m *DiagnosticMutex) Unlock() {
+	if m.Threshold > 0 {
+		m.infoMu.Lock()
+		m.heldSince = time.Time{}
+		m.holderStack = nil
+		m.infoMu.Unlock()
+	}
+	m.mu.Unlock()
+}
+
+func (m *DiagnosticMutex) recordHolder() {
+	buf := make([]byte, 4096)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	m.infoMu.Lock()
+	m.heldSince = time.Now()
+	m.holderStack = buf
+	m.infoMu.Unlock()
+}
+
+func (m *DiagnosticMutex) report(waited time.Duration) {
+	m.infoMu.Lock()
+	d := LockDiagnostic{Waited: waited, HeldSince: m.heldSince, HolderStack: string(m.holderStack)}
+	m.infoMu.Unlock()
+
+	reportSlowLock(m.OnSlowLock, d)
+}
+
+// reportSlowLock hands d to onSlow, or logs it through slog.Default when
+// onSlow is nil.
+func reportSlowLock(onSlow func(LockDiagnostic), d LockDiagnostic) {
+	if onSlow != nil {
+		onSlow(d)
+		return
+	}
+	slog.Warn("slow mutex acquisition",
+		slog.Duration("waited", d.Waited),
+		slog.Time("held_since", d.HeldSince),
+		slog.String("holder_stack", d.HolderStack),
+	)
+}
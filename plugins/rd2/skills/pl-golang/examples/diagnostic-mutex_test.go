@@ -0,0 +1,83 @@
+package examples
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// holdLock acquires m and keeps it for d; its name shows up in the holder
+// stack.
+func holdLock(m *DiagnosticMutex, acquired chan<- struct{}, d time.Duration) {
+	m.Lock()
+	close(acquired)
+	time.Sleep(d)
+	m.Unlock()
+}
+
+func TestDiagnosticMutexReportsSlowLock(t *testing.T) {
+	reports := make(chan LockDiagnostic, 1)
+	m := &DiagnosticMutex{
+		Threshold:  20 * time.Millisecond,
+		OnSlowLock: func(d LockDiagnostic) { reports <- d },
+	}
+
+	acquired := make(chan struct{})
+	go holdLock(m, acquired, 150*time.Millisecond)
+	<-acquired
+
+	m.Lock()
+	m.Unlock()
+
+	select {
+	case d := <-reports:
+		if !strings.Contains(d.HolderStack, "holdLock") {
+			t.Errorf("expected holder stack to name holdLock, got:\n%s", d.HolderStack)
+		}
+		if d.Waited < 20*time.Millisecond {
+			t.Errorf("expected wait >= threshold, got %v", d.Waited)
+		}
+		if d.HeldSince.IsZero() {
+			t.Error("expected holder acquisition time")
+		}
+	default:
+		t.Fatal("expected a slow-lock diagnostic")
+	}
+}
+
+func TestDiagnosticMutexQuietWhenFast(t *testing.T) {
+	var mu sync.Mutex
+	fired := 0
+	m := &DiagnosticMutex{
+		Threshold:  time.Second,
+		OnSlowLock: func(LockDiagnostic) { mu.Lock(); fired++; mu.Unlock() },
+	}
+
+	acquired := make(chan struct{})
+	go holdLock(m, acquired, 5*time.Millisecond)
+	<-acquired
+	m.Lock()
+	m.Unlock()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if fired != 0 {
+		t.Errorf("expected no diagnostic for a fast acquisition, got %d", fired)
+	}
+}
+
+func TestDiagnosticMutexDisabled(t *testing.T) {
+	fired := false
+	m := &DiagnosticMutex{OnSlowLock: func(LockDiagnostic) { fired = true }}
+
+	acquired := make(chan struct{})
+	go holdLock(m, acquired, 30*time.Millisecond)
+	<-acquired
+	m.Lock()
+	m.Unlock()
+
+	if fired {
+		t.Error("expected diagnostics to be off with zero threshold")
+	}
+}
@@ -0,0 +1,154 @@
+package examples
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// DiagnosticRWMutex is a reader/writer lock whose acquisitions can be
+// abandoned through a context, which sync.RWMutex does not allow. Like
+// sync.RWMutex, a waiting writer blocks new readers so writers are not
+// starved.
+//
+// With a non-zero Threshold it reports waits that take longer, as
+// DiagnosticMutex does. HeldSince and HolderStack are only filled in while a
+// writer holds the lock; readers are not tracked individually.
+type DiagnosticRWMutex struct {
+	Threshold  time.Duration        // 0 disables diagnostics
+	OnSlowLock func(LockDiagnostic) // nil logs through slog.Default
+
+	mu             sync.Mutex
+	cv             *CondVar
+	readers        int
+	writer         bool
+	writersWaiting int
+	heldSince      time.Time
+	holderStack    []byte
+}
+
+// Lock acquires the write lock, returning ctx.Err() if ctx is done first.
+func (m *DiagnosticRWMutex) Lock(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.writer || m.readers > 0 {
+		m.writersWaiting++
+		err := m.wait(ctx, func() bool { return m.writer || m.readers > 0 })
+		m.writersWaiting--
+		if err != nil {
+			// Readers held back by this writer may proceed now.
+			m.cond().Broadcast()
+			return err
+		}
+	}
+	m.acquireWrite()
+	return nil
+}
+
+// RLock acquires a read lock, returning ctx.Err() if ctx is done first.
+func (m *DiagnosticRWMutex) RLock(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.wait(ctx, m.readBlocked); err != nil {
+		return err
+	}
+	m.readers++
+	return nil
+}
+
+// TryLock acquires the write lock if it is free, without blocking.
+func (m *DiagnosticRWMutex) TryLock() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.writer || m.readers > 0 {
+		return false
+	}
+	m.acquireWrite()
+	return true
+}
+
+// TryRLock acquires a read lock if no writer holds or awaits the lock,
+// without blocking.
+func (m *DiagnosticRWMutex) TryRLock() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.readBlocked() {
+		return false
+	}
+	m.readers++
+	return true
+}
+
+// Unlock releases the write lock. It panics if the write lock is not held.
+func (m *DiagnosticRWMutex) Unlock() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.writer {
+		panic("examples: Unlock of unlocked DiagnosticRWMutex")
+	}
+	m.writer = false
+	m.heldSince, m.holderStack = time.Time{}, nil
+	m.cond().Broadcast()
+}
+
+// RUnlock releases a read lock. It panics if no read lock is held.
+func (m *DiagnosticRWMutex) RUnlock() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.readers == 0 {
+		panic("examples: RUnlock of unlocked DiagnosticRWMutex")
+	}
+	m.readers--
+	if m.readers == 0 {
+		m.cond().Broadcast()
+	}
+}
+
+func (m *DiagnosticRWMutex) readBlocked() bool {
+	return m.writer || m.writersWaiting > 0
+}
+
+// wait blocks while blocked reports true. m.mu must be held.
+func (m *DiagnosticRWMutex) wait(ctx context.Context, blocked func() bool) error {
+	if !blocked() {
+		return nil
+	}
+	if m.Threshold > 0 {
+		start := time.Now()
+		timer := time.AfterFunc(m.Threshold, func() { m.report(time.Since(start)) })
+		defer timer.Stop()
+	}
+	for blocked() {
+		if err := m.cond().Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// acquireWrite marks the write lock held. m.mu must be held.
+func (m *DiagnosticRWMutex) acquireWrite() {
+	m.writer = true
+	if m.Threshold > 0 {
+		buf := make([]byte, 4096)
+		m.holderStack = buf[:runtime.Stack(buf, false)]
+		m.heldSince = time.Now()
+	}
+}
+
+// cond returns the condition variable, creating it on first use so the zero
+// value is ready to use. m.mu must be held.
+func (m *DiagnosticRWMutex) cond() *CondVar {
+	if m.cv == nil {
+		m.cv = NewCondVar(&m.mu)
+	}
+	return m.cv
+}
+
+func (m *DiagnosticRWMutex) report(waited time.Duration) {
+	m.mu.Lock()
+	d := LockDiagnostic{Waited: waited, HeldSince: m.heldSince, HolderStack: string(m.holderStack)}
+	m.mu.Unlock()
+	reportSlowLock(m.OnSlowLock, d)
+}
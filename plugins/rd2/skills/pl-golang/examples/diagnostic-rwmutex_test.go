@@ -0,0 +1,175 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDiagnosticRWMutexConcurrentReaders(t *testing.T) {
+	var m DiagnosticRWMutex
+	const readers = 5
+
+	// Every reader holds its lock until all of them have acquired one, which
+	// only completes if readers do not block each other.
+	var all sync.WaitGroup
+	all.Add(readers)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := m.RLock(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			all.Done()
+			all.Wait()
+			m.RUnlock()
+		}()
+	}
+	go func() { wg.Wait(); close(done) }()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("concurrent readers blocked each other")
+	}
+}
+
+func TestDiagnosticRWMutexWriterExcludes(t *testing.T) {
+	var m DiagnosticRWMutex
+	if err := m.Lock(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.TryLock() || m.TryRLock() {
+		t.Fatal("expected Try* to fail while write-locked")
+	}
+
+	tests := []struct {
+		name    string
+		acquire func(context.Context) error
+	}{
+		{"reader", m.RLock},
+		{"writer", m.Lock},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			if err := tt.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected DeadlineExceeded, got %v", err)
+			}
+		})
+	}
+
+	m.Unlock()
+	if !m.TryRLock() {
+		t.Fatal("expected TryRLock to succeed once unlocked")
+	}
+	if m.TryLock() {
+		t.Fatal("expected TryLock to fail while read-locked")
+	}
+	m.RUnlock()
+	if !m.TryLock() {
+		t.Fatal("expected TryLock to succeed once all readers left")
+	}
+	m.Unlock()
+}
+
+func TestDiagnosticRWMutexWaitingWriterBlocksReaders(t *testing.T) {
+	var m DiagnosticRWMutex
+	if err := m.RLock(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	writerCtx, cancelWriter := context.WithCancel(context.Background())
+	writerErr := make(chan error, 1)
+	go func() { writerErr <- m.Lock(writerCtx) }()
+
+	// Once the writer is queued, new readers must wait behind it.
+	deadline := time.Now().Add(time.Second)
+	for m.TryRLock() {
+		m.RUnlock()
+		if time.Now().After(deadline) {
+			t.Fatal("writer never started waiting")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	readerErr := make(chan error, 1)
+	go func() { readerErr <- m.RLock(context.Background()) }()
+
+	// Abandoning the writer releases the readers queued behind it.
+	cancelWriter()
+	if err := <-writerErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Canceled, got %v", err)
+	}
+	select {
+	case err := <-readerErr:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("reader not released after the waiting writer gave up")
+	}
+	m.RUnlock()
+	m.RUnlock()
+}
+
+func TestDiagnosticRWMutexReportsSlowLock(t *testing.T) {
+	reports := make(chan LockDiagnostic, 1)
+	m := &DiagnosticRWMutex{
+		Threshold:  20 * time.Millisecond,
+		OnSlowLock: func(d LockDiagnostic) { reports <- d },
+	}
+
+	if err := m.Lock(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		m.Unlock()
+	}()
+
+	if err := m.RLock(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.RUnlock()
+
+	select {
+	case d := <-reports:
+		if !strings.Contains(d.HolderStack, "TestDiagnosticRWMutexReportsSlowLock") {
+			t.Errorf("expected holder stack to name the test, got:\n%s", d.HolderStack)
+		}
+		if d.Waited < 20*time.Millisecond {
+			t.Errorf("expected wait >= threshold, got %v", d.Waited)
+		}
+	default:
+		t.Fatal("expected a slow-lock diagnostic")
+	}
+}
+
+func TestDiagnosticRWMutexUnlockPanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		unlock func(*DiagnosticRWMutex)
+	}{
+		{"Unlock", (*DiagnosticRWMutex).Unlock},
+		{"RUnlock", (*DiagnosticRWMutex).RUnlock},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			tt.unlock(&DiagnosticRWMutex{})
+		})
+	}
+}
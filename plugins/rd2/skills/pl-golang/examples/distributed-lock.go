@@ -0,0 +1,61 @@
+package examples
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrLockNotHeld is returned by Unlock when the lock is not held, e.g. on a
+// second Unlock or after a backing store expired the lock.
+var ErrLockNotHeld = errors.New("lock not held")
+
+// DistributedLock is a mutual-exclusion lock that may be shared across
+// processes. Code written against it can run on MemoryLock in tests and a
+// networked implementation in production.
+//
+// A Redis-backed implementation would typically hold a key and a random
+// token: Lock retries SET key token NX PX ttl until it succeeds or ctx is
+// done, and Unlock runs a Lua script that deletes the key only if it still
+// holds the token, returning ErrLockNotHeld otherwise. With etcd, Lock would
+// use concurrency.NewMutex(session, key).Lock(ctx), where the session lease
+// releases the lock if the process dies.
+type DistributedLock interface {
+	// Lock blocks until the lock is acquired or ctx is done.
+	Lock(ctx context.Context) error
+	// Unlock releases the lock, returning ErrLockNotHeld if it is not held.
+	Unlock() error
+}
+
+// MemoryLock is an in-process DistributedLock, useful as a test double and
+// for single-instance deployments.
+type MemoryLock struct {
+	held chan struct{}
+}
+
+var _ DistributedLock = (*MemoryLock)(nil)
+
+// NewMemoryLock returns an unlocked MemoryLock.
+func NewMemoryLock() *MemoryLock {
+	return &MemoryLock{held: make(chan struct{}, 1)}
+}
+
+// Lock acquires the lock, waiting until it is free or ctx is done.
+func (l *MemoryLock) Lock(ctx context.Context) error {
+	select {
+	case l.held <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Unlock releases the lock. Unlocking a lock that is not held returns
+// ErrLockNotHeld instead of panicking like sync.Mutex.
+func (l *MemoryLock) Unlock() error {
+	select {
+	case <-l.held:
+		return nil
+	default:
+		return ErrLockNotHeld
+	}
+}
@@ -0,0 +1,74 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryLockMutualExclusion(t *testing.T) {
+	var lock DistributedLock = NewMemoryLock()
+	counter, inside := 0, 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := lock.Lock(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			inside++
+			if inside != 1 {
+				t.Errorf("expected one holder, got %d", inside)
+			}
+			counter++
+			inside--
+			if err := lock.Unlock(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if counter != 50 {
+		t.Errorf("expected 50 increments, got %d", counter)
+	}
+}
+
+func TestMemoryLockCanceled(t *testing.T) {
+	lock := NewMemoryLock()
+	if err := lock.Lock(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := lock.Lock(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded while held, got %v", err)
+	}
+}
+
+func TestMemoryLockDoubleUnlock(t *testing.T) {
+	lock := NewMemoryLock()
+	if err := lock.Unlock(); !errors.Is(err, ErrLockNotHeld) {
+		t.Errorf("expected ErrLockNotHeld before locking, got %v", err)
+	}
+	if err := lock.Lock(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := lock.Unlock(); !errors.Is(err, ErrLockNotHeld) {
+		t.Errorf("expected ErrLockNotHeld on second unlock, got %v", err)
+	}
+
+	// The lock is still usable afterwards.
+	if err := lock.Lock(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,9 @@
+// Package examples collects small, tested building blocks that back the
+// patterns described in the pl-golang references.
+//
+// The standalone demo programs in this directory (concurrency.go,
+// context-usage.go, error-handling.go, table-driven-test.go) carry a
+// "go:build ignore" constraint and are run individually with `go run`.
+// Everything else belongs to this package and depends only on the standard
+// library (Go 1.22+).
+package examples
@@ -0,0 +1,50 @@
+package examples
+
+import (
+	"context"
+	"sync"
+)
+
+// Drainer processes items from a Queue with a fixed set of workers, the
+// consumer side of an outbox. A failing item is retried with Backoff; once
+// Backoff.MaxAttempts is used up it goes to DeadLetter so one bad item cannot
+// block the rest.
+//
+// An item interrupted by cancellation is neither retried nor dead-lettered.
+// With a durable outbox it simply stays unacknowledged and is picked up on
+// the next run.
+type Drainer[T any] struct {
+	Queue      *Queue[T]
+	Workers    int           // values < 1 mean 1
+	Backoff    BackoffConfig // zero value means one attempt, no retries
+	Process    func(context.Context, T) error
+	DeadLetter func(item T, err error) // nil drops failed items
+}
+
+// Run drains the queue until ctx is done, then waits for in-flight items to
+// stop and returns ctx.Err().
+func (d *Drainer[T]) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for i := 0; i < max(d.Workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.work(ctx)
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+func (d *Drainer[T]) work(ctx context.Context) {
+	for {
+		item, err := d.Queue.Dequeue(ctx)
+		if err != nil {
+			return
+		}
+		err = Retry(ctx, d.Backoff, func() error { return d.Process(ctx, item) })
+		if err != nil && ctx.Err() == nil && d.DeadLetter != nil {
+			d.DeadLetter(item, err)
+		}
+	}
+}
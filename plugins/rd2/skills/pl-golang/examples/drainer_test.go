@@ -0,0 +1,166 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type outboxMsg struct {
+	ID       int
+	FailFor  int  // attempts that fail before succeeding
+	Poisoned bool // always fails
+}
+
+// drainHarness records processed and dead-lettered messages.
+type drainHarness struct {
+	mu       sync.Mutex
+	attempts map[int]int
+	done     chan int
+	dead     chan int
+	deadErr  error
+}
+
+func newDrainHarness() *drainHarness {
+	return &drainHarness{attempts: make(map[int]int), done: make(chan int, 10), dead: make(chan int, 10)}
+}
+
+func (h *drainHarness) process(ctx context.Context, m outboxMsg) error {
+	h.mu.Lock()
+	h.attempts[m.ID]++
+	n := h.attempts[m.ID]
+	h.mu.Unlock()
+
+	if m.Poisoned || n <= m.FailFor {
+		return errDependency
+	}
+	h.done <- m.ID
+	return nil
+}
+
+func (h *drainHarness) deadLetter(m outboxMsg, err error) {
+	h.mu.Lock()
+	h.deadErr = err
+	h.mu.Unlock()
+	h.dead <- m.ID
+}
+
+func startDrainer(t *testing.T, h *drainHarness, msgs ...outboxMsg) (stop func() error) {
+	t.Helper()
+	q := NewQueue[outboxMsg](10)
+	for _, m := range msgs {
+		if err := q.Enqueue(context.Background(), m); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	d := &Drainer[outboxMsg]{
+		Queue:      q,
+		Workers:    2,
+		Backoff:    BackoffConfig{MaxAttempts: 3, Initial: time.Millisecond},
+		Process:    h.process,
+		DeadLetter: h.deadLetter,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() { result <- d.Run(ctx) }()
+	return func() error {
+		cancel()
+		select {
+		case err := <-result:
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("drainer did not stop after cancellation")
+			return nil
+		}
+	}
+}
+
+func expectIDs(t *testing.T, ch <-chan int, want ...int) {
+	t.Helper()
+	got := make(map[int]bool)
+	for range want {
+		select {
+		case id := <-ch:
+			got[id] = true
+		case <-time.After(time.Second):
+			t.Fatalf("expected ids %v, got %v", want, got)
+		}
+	}
+	for _, id := range want {
+		if !got[id] {
+			t.Errorf("expected id %d, got %v", id, got)
+		}
+	}
+}
+
+func TestDrainerProcessesAll(t *testing.T) {
+	h := newDrainHarness()
+	stop := startDrainer(t, h, outboxMsg{ID: 1}, outboxMsg{ID: 2}, outboxMsg{ID: 3})
+
+	expectIDs(t, h.done, 1, 2, 3)
+	if err := stop(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Canceled, got %v", err)
+	}
+	if len(h.dead) != 0 {
+		t.Errorf("expected no dead letters, got %d", len(h.dead))
+	}
+}
+
+func TestDrainerRetriesTransientFailure(t *testing.T) {
+	h := newDrainHarness()
+	stop := startDrainer(t, h, outboxMsg{ID: 7, FailFor: 2})
+	defer stop()
+
+	expectIDs(t, h.done, 7)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if n := h.attempts[7]; n != 3 {
+		t.Errorf("expected 3 attempts, got %d", n)
+	}
+}
+
+func TestDrainerDeadLettersPermanentFailure(t *testing.T) {
+	h := newDrainHarness()
+	stop := startDrainer(t, h, outboxMsg{ID: 9, Poisoned: true}, outboxMsg{ID: 10})
+	defer stop()
+
+	expectIDs(t, h.dead, 9)
+	expectIDs(t, h.done, 10)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if n := h.attempts[9]; n != 3 {
+		t.Errorf("expected 3 attempts before dead-lettering, got %d", n)
+	}
+	if !errors.Is(h.deadErr, errDependency) {
+		t.Errorf("expected dead letter to carry the last error, got %v", h.deadErr)
+	}
+}
+
+func TestDrainerZeroBackoff(t *testing.T) {
+	h := newDrainHarness()
+	q := NewQueue[outboxMsg](10)
+	for _, m := range []outboxMsg{{ID: 1}, {ID: 2, Poisoned: true}} {
+		if err := q.Enqueue(context.Background(), m); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	d := &Drainer[outboxMsg]{Queue: q, Process: h.process, DeadLetter: h.deadLetter}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() { result <- d.Run(ctx) }()
+
+	expectIDs(t, h.done, 1)
+	expectIDs(t, h.dead, 2)
+	cancel()
+	<-result
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.attempts[1] != 1 || h.attempts[2] != 1 {
+		t.Errorf("expected one attempt per item without Backoff, got %v", h.attempts)
+	}
+}
@@ -0,0 +1,50 @@
+package examples
+
+import (
+	"slices"
+	"sync"
+)
+
+// Emitter dispatches typed in-process events to registered handlers. It is
+// safe for concurrent use; handlers may themselves call On or unsubscribe.
+type Emitter[E any] struct {
+	mu       sync.RWMutex
+	nextID   uint64
+	handlers []emitterHandler[E]
+}
+
+type emitterHandler[E any] struct {
+	id uint64
+	fn func(E)
+}
+
+// On registers handler and returns a func that unregisters it. Calling the
+// returned func more than once is harmless.
+func (em *Emitter[E]) On(handler func(E)) (unsubscribe func()) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	em.nextID++
+	id := em.nextID
+	em.handlers = append(em.handlers, emitterHandler[E]{id: id, fn: handler})
+
+	return func() {
+		em.mu.Lock()
+		defer em.mu.Unlock()
+		em.handlers = slices.DeleteFunc(em.handlers, func(h emitterHandler[E]) bool {
+			return h.id == id
+		})
+	}
+}
+
+// Emit calls every handler registered when Emit starts, synchronously and in
+// registration order. A slow handler delays both the caller and the handlers
+// after it.
+func (em *Emitter[E]) Emit(e E) {
+	em.mu.RLock()
+	handlers := slices.Clone(em.handlers)
+	em.mu.RUnlock()
+
+	for _, h := range handlers {
+		h.fn(e)
+	}
+}
@@ -0,0 +1,82 @@
+package examples
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type orderPlaced struct {
+	ID int
+}
+
+func TestEmitterDeliversToAllHandlers(t *testing.T) {
+	var em Emitter[orderPlaced]
+	var got []string
+	em.On(func(e orderPlaced) { got = append(got, "billing") })
+	em.On(func(e orderPlaced) { got = append(got, "shipping") })
+
+	em.Emit(orderPlaced{ID: 1})
+
+	if want := []string{"billing", "shipping"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestEmitterUnsubscribe(t *testing.T) {
+	var em Emitter[orderPlaced]
+	var a, b int
+	unsubA := em.On(func(orderPlaced) { a++ })
+	em.On(func(orderPlaced) { b++ })
+
+	em.Emit(orderPlaced{})
+	unsubA()
+	unsubA() // idempotent
+	em.Emit(orderPlaced{})
+
+	if a != 1 || b != 2 {
+		t.Errorf("expected a=1 b=2, got a=%d b=%d", a, b)
+	}
+}
+
+func TestEmitterHandlerMayUnsubscribeItself(t *testing.T) {
+	var em Emitter[orderPlaced]
+	var calls int
+	var unsub func()
+	unsub = em.On(func(orderPlaced) {
+		calls++
+		unsub()
+	})
+
+	em.Emit(orderPlaced{})
+	em.Emit(orderPlaced{})
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestEmitterConcurrentOnEmit(t *testing.T) {
+	var em Emitter[orderPlaced]
+	var total atomic.Int64
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			unsub := em.On(func(e orderPlaced) { total.Add(int64(e.ID)) })
+			em.Emit(orderPlaced{ID: 1})
+			unsub()
+		}()
+		go func() {
+			defer wg.Done()
+			em.Emit(orderPlaced{ID: 1})
+		}()
+	}
+	wg.Wait()
+
+	if total.Load() == 0 {
+		t.Error("expected some events to be delivered")
+	}
+}
@@ -0,0 +1,96 @@
+package examples
+
+import (
+	"context"
+	"sync"
+)
+
+// Group runs goroutines with bounded concurrency and cancels its context on
+// the first error, in the spirit of golang.org/x/sync/errgroup.
+type Group struct {
+	cancel context.CancelCauseFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	errOnce sync.Once
+	err     error
+}
+
+// NewGroup returns a Group and a context derived from ctx that is canceled
+// when a goroutine fails or Wait returns. A limit <= 0 means unbounded.
+func NewGroup(ctx context.Context, limit int) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := &Group{cancel: cancel}
+	if limit > 0 {
+		g.sem = make(chan struct{}, limit)
+	}
+	return g, ctx
+}
+
+// Go runs fn in a new goroutine, blocking first while limit goroutines are
+// already active.
+func (g *Group) Go(fn func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.wg.Add(1)
+	go func() {
+		defer func() {
+			if g.sem != nil {
+				<-g.sem
+			}
+			g.wg.Done()
+		}()
+		if err := fn(); err != nil {
+			g.errOnce.Do(func() {
+				g.err = err
+				g.cancel(err)
+			})
+		}
+	}()
+}
+
+// Wait blocks until all goroutines return and reports the first error.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(nil)
+	return g.err
+}
+
+// TypedGroup is a Group whose goroutines produce values. Successful results
+// are collected in completion order.
+type TypedGroup[T any] struct {
+	g       *Group
+	mu      sync.Mutex
+	results []T
+}
+
+// NewTypedGroup is the result-collecting counterpart of NewGroup.
+func NewTypedGroup[T any](ctx context.Context, limit int) (*TypedGroup[T], context.Context) {
+	g, ctx := NewGroup(ctx, limit)
+	return &TypedGroup[T]{g: g}, ctx
+}
+
+// Go runs fn in a new goroutine, subject to the group's limit.
+func (tg *TypedGroup[T]) Go(fn func() (T, error)) {
+	tg.g.Go(func() error {
+		v, err := fn()
+		if err != nil {
+			return err
+		}
+		tg.mu.Lock()
+		tg.results = append(tg.results, v)
+		tg.mu.Unlock()
+		return nil
+	})
+}
+
+// Wait blocks until all goroutines return. On success it returns the results
+// in completion order; on failure it returns only the first error, since
+// results gathered after cancellation are incomplete.
+func (tg *TypedGroup[T]) Wait() ([]T, error) {
+	if err := tg.g.Wait(); err != nil {
+		return nil, err
+	}
+	return tg.results, nil
+}
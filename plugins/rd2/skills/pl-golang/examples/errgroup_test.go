@@ -0,0 +1,95 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGroupFirstErrorCancels(t *testing.T) {
+	g, ctx := NewGroup(context.Background(), 0)
+	errBoom := errors.New("boom")
+
+	g.Go(func() error { return errBoom })
+	g.Go(func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if err := g.Wait(); !errors.Is(err, errBoom) {
+		t.Errorf("expected first error, got %v", err)
+	}
+	if !errors.Is(context.Cause(ctx), errBoom) {
+		t.Errorf("expected context cause to be the first error, got %v", context.Cause(ctx))
+	}
+}
+
+func TestTypedGroupCollectsResults(t *testing.T) {
+	g, _ := NewTypedGroup[int](context.Background(), 0)
+
+	// Stagger completion so the expected completion order is deterministic.
+	for i := 3; i >= 1; i-- {
+		g.Go(func() (int, error) {
+			time.Sleep(time.Duration(i) * 15 * time.Millisecond)
+			return i, nil
+		})
+	}
+
+	got, err := g.Wait()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("expected results in completion order [1 2 3], got %v", got)
+	}
+}
+
+func TestTypedGroupFirstErrorDiscardsResults(t *testing.T) {
+	g, ctx := NewTypedGroup[string](context.Background(), 0)
+	errFail := errors.New("replica down")
+
+	g.Go(func() (string, error) { return "", errFail })
+	g.Go(func() (string, error) {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(time.Second):
+			return "late", nil
+		}
+	})
+
+	got, err := g.Wait()
+	if !errors.Is(err, errFail) {
+		t.Errorf("expected first error, got %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected no results on failure, got %v", got)
+	}
+}
+
+func TestTypedGroupLimit(t *testing.T) {
+	const limit = 2
+	g, _ := NewTypedGroup[int](context.Background(), limit)
+
+	var peaks peakTracker
+	for i := 0; i < 8; i++ {
+		g.Go(func() (int, error) {
+			defer peaks.enter()()
+			time.Sleep(5 * time.Millisecond)
+			return i, nil
+		})
+	}
+
+	got, err := g.Wait()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 8 {
+		t.Errorf("expected 8 results, got %d", len(got))
+	}
+	if p := peaks.Peak(); p > limit {
+		t.Errorf("expected at most %d concurrent goroutines, saw %d", limit, p)
+	}
+}
@@ -0,0 +1,105 @@
+package examples
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrorCode classifies an error independently of its message so callers can
+// branch on it and transports can map it to a status.
+type ErrorCode int
+
+//go:generate stringer -type=ErrorCode -trimprefix=Code
+
+const (
+	CodeUnknown ErrorCode = iota
+	CodeInvalidArgument
+	CodeNotFound
+	CodeAlreadyExists
+	CodePermissionDenied
+	CodeUnauthenticated
+	CodeDeadlineExceeded
+	CodeUnavailable
+	CodeInternal
+)
+
+// HTTPStatus maps the code to the closest HTTP status.
+func (c ErrorCode) HTTPStatus() int {
+	switch c {
+	case CodeInvalidArgument:
+		return http.StatusBadRequest
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeAlreadyExists:
+		return http.StatusConflict
+	case CodePermissionDenied:
+		return http.StatusForbidden
+	case CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case CodeDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case CodeUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// CodedError attaches an ErrorCode to a message and an optional cause.
+type CodedError struct {
+	Code    ErrorCode
+	Message string
+	Err     error
+}
+
+func (e *CodedError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *CodedError) Unwrap() error {
+	return e.Err
+}
+
+// NewCoded returns a *CodedError with the given code and message.
+func NewCoded(code ErrorCode, msg string) error {
+	return &CodedError{Code: code, Message: msg}
+}
+
+// CodeOf returns the code of the first *CodedError in err's chain.
+// It reports false (and CodeUnknown) when there is none.
+func CodeOf(err error) (ErrorCode, bool) {
+	var ce *CodedError
+	if errors.As(err, &ce) {
+		return ce.Code, true
+	}
+	return CodeUnknown, false
+}
+
+// ErrorResponse is the JSON body written by WriteError.
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// WriteError writes err as a JSON ErrorResponse with the HTTP status mapped
+// from its code. Only the message of a CodedError is exposed; any other error
+// is reported as a generic internal error so details never leak to clients.
+func WriteError(w http.ResponseWriter, err error) {
+	resp := ErrorResponse{Code: CodeInternal.String(), Message: "internal error"}
+	status := http.StatusInternalServerError
+
+	var ce *CodedError
+	if errors.As(err, &ce) {
+		resp = ErrorResponse{Code: ce.Code.String(), Message: ce.Message}
+		status = ce.Code.HTTPStatus()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,136 @@
+package examples
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorCodeString(t *testing.T) {
+	tests := []struct {
+		code     ErrorCode
+		expected string
+	}{
+		{CodeUnknown, "Unknown"},
+		{CodeInvalidArgument, "InvalidArgument"},
+		{CodeNotFound, "NotFound"},
+		{CodeAlreadyExists, "AlreadyExists"},
+		{CodePermissionDenied, "PermissionDenied"},
+		{CodeUnauthenticated, "Unauthenticated"},
+		{CodeDeadlineExceeded, "DeadlineExceeded"},
+		{CodeUnavailable, "Unavailable"},
+		{CodeInternal, "Internal"},
+		{ErrorCode(99), "ErrorCode(99)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := tt.code.String(); got != tt.expected {
+				t.Errorf("String() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestErrorCodeHTTPStatus(t *testing.T) {
+	tests := []struct {
+		code     ErrorCode
+		expected int
+	}{
+		{CodeUnknown, http.StatusInternalServerError},
+		{CodeInvalidArgument, http.StatusBadRequest},
+		{CodeNotFound, http.StatusNotFound},
+		{CodeAlreadyExists, http.StatusConflict},
+		{CodePermissionDenied, http.StatusForbidden},
+		{CodeUnauthenticated, http.StatusUnauthorized},
+		{CodeDeadlineExceeded, http.StatusGatewayTimeout},
+		{CodeUnavailable, http.StatusServiceUnavailable},
+		{CodeInternal, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			if got := tt.code.HTTPStatus(); got != tt.expected {
+				t.Errorf("HTTPStatus() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	base := NewCoded(CodeNotFound, "user 42")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected ErrorCode
+		ok       bool
+	}{
+		{"direct", base, CodeNotFound, true},
+		{"wrapped once", fmt.Errorf("get user: %w", base), CodeNotFound, true},
+		{"wrapped twice", fmt.Errorf("handler: %w", fmt.Errorf("get user: %w", base)), CodeNotFound, true},
+		{"joined", errors.Join(errors.New("other"), base), CodeNotFound, true},
+		{"plain error", errors.New("boom"), CodeUnknown, false},
+		{"nil", nil, CodeUnknown, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, ok := CodeOf(tt.err)
+			if ok != tt.ok || code != tt.expected {
+				t.Errorf("CodeOf() = (%v, %v), want (%v, %v)", code, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCodedErrorMessage(t *testing.T) {
+	err := &CodedError{Code: CodeUnavailable, Message: "db down", Err: errors.New("dial tcp")}
+
+	if got := err.Error(); got != "Unavailable: db down: dial tcp" {
+		t.Errorf("unexpected message: %s", got)
+	}
+
+	if got := NewCoded(CodeInvalidArgument, "bad id").Error(); got != "InvalidArgument: bad id" {
+		t.Errorf("unexpected message: %s", got)
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantMessage string
+	}{
+		{"coded", NewCoded(CodeNotFound, "user 42"), http.StatusNotFound, "NotFound", "user 42"},
+		{"wrapped coded", fmt.Errorf("handler: %w", NewCoded(CodeInvalidArgument, "bad id")), http.StatusBadRequest, "InvalidArgument", "bad id"},
+		{"plain error hidden", errors.New("pq: password authentication failed"), http.StatusInternalServerError, "Internal", "internal error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			WriteError(rec, tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("expected JSON content type, got %q", ct)
+			}
+
+			var body ErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body.Code != tt.wantCode || body.Message != tt.wantMessage {
+				t.Errorf("expected {%s %s}, got %+v", tt.wantCode, tt.wantMessage, body)
+			}
+		})
+	}
+}
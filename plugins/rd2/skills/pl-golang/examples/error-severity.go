@@ -0,0 +1,50 @@
+package examples
+
+import "errors"
+
+// Severity ranks how serious an error is so logging and alerting can filter
+// on it.
+type Severity int
+
+//go:generate stringer -type=Severity -trimprefix=Severity
+
+const (
+	SeverityDebug Severity = iota
+	SeverityInfo
+	SeverityWarn
+	SeverityError
+	SeverityFatal
+)
+
+type severityError struct {
+	err error
+	sev Severity
+}
+
+func (e *severityError) Error() string { return e.err.Error() }
+
+func (e *severityError) Unwrap() error { return e.err }
+
+// WithSeverity annotates err with sev. It returns nil if err is nil.
+func WithSeverity(err error, sev Severity) error {
+	if err == nil {
+		return nil
+	}
+	return &severityError{err: err, sev: sev}
+}
+
+// SeverityOf returns the outermost severity attached to err's chain, or
+// SeverityError if none was attached.
+func SeverityOf(err error) Severity {
+	var se *severityError
+	if errors.As(err, &se) {
+		return se.sev
+	}
+	return SeverityError
+}
+
+// AtLeast reports whether err is non-nil with a severity of at least
+// threshold.
+func AtLeast(err error, threshold Severity) bool {
+	return err != nil && SeverityOf(err) >= threshold
+}
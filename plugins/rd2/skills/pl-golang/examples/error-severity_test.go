@@ -0,0 +1,84 @@
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSeverityString(t *testing.T) {
+	names := map[Severity]string{
+		SeverityDebug: "Debug",
+		SeverityInfo:  "Info",
+		SeverityWarn:  "Warn",
+		SeverityError: "Error",
+		SeverityFatal: "Fatal",
+	}
+	for sev, want := range names {
+		if got := sev.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestSeverityOf(t *testing.T) {
+	base := errors.New("disk almost full")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected Severity
+	}{
+		{"unset defaults to error", base, SeverityError},
+		{"attached", WithSeverity(base, SeverityWarn), SeverityWarn},
+		{"wrapped", fmt.Errorf("monitor: %w", WithSeverity(base, SeverityInfo)), SeverityInfo},
+		{"outermost wins", WithSeverity(WithSeverity(base, SeverityDebug), SeverityFatal), SeverityFatal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SeverityOf(tt.err); got != tt.expected {
+				t.Errorf("SeverityOf() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWithSeverityPreservesChain(t *testing.T) {
+	err := WithSeverity(ErrPoolClosed, SeverityWarn)
+
+	if !errors.Is(err, ErrPoolClosed) {
+		t.Error("expected errors.Is to see through the severity wrapper")
+	}
+	if err.Error() != ErrPoolClosed.Error() {
+		t.Errorf("expected message unchanged, got %q", err.Error())
+	}
+	if WithSeverity(nil, SeverityFatal) != nil {
+		t.Error("expected nil for nil error")
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	warn := WithSeverity(errors.New("slow"), SeverityWarn)
+
+	tests := []struct {
+		name     string
+		err      error
+		min      Severity
+		expected bool
+	}{
+		{"above", warn, SeverityInfo, true},
+		{"equal", warn, SeverityWarn, true},
+		{"below", warn, SeverityError, false},
+		{"default error", errors.New("x"), SeverityError, true},
+		{"nil", nil, SeverityDebug, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AtLeast(tt.err, tt.min); got != tt.expected {
+				t.Errorf("AtLeast() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
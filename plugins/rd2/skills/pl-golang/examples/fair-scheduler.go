@@ -0,0 +1,104 @@
+package examples
+
+import (
+	"context"
+	"sync"
+)
+
+// FairScheduler runs jobs from many tenants on a shared set of workers,
+// taking one job from each tenant with pending work in turn. A tenant that
+// floods the scheduler only lengthens its own queue; others still get a
+// slot every round. Tenants with nothing queued are not visited at all.
+//
+// When ctx is done, workers finish their current job and exit; queued jobs
+// are discarded and later Submits are ignored.
+type FairScheduler[K comparable] struct {
+	mu      sync.Mutex
+	cv      *CondVar
+	queues  map[K][]func()
+	active  []K // tenants with queued jobs, in round-robin order
+	stopped bool
+	done    chan struct{}
+}
+
+// NewFairScheduler starts workers goroutines (at least one) that run until
+// ctx is done.
+func NewFairScheduler[K comparable](ctx context.Context, workers int) *FairScheduler[K] {
+	s := &FairScheduler[K]{
+		queues: make(map[K][]func()),
+		done:   make(chan struct{}),
+	}
+	s.cv = NewCondVar(&s.mu)
+
+	var wg sync.WaitGroup
+	for i := 0; i < max(workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(s.done)
+	}()
+	return s
+}
+
+// Submit queues job for tenant.
+func (s *FairScheduler[K]) Submit(tenant K, job func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return
+	}
+	q := s.queues[tenant]
+	if len(q) == 0 {
+		s.active = append(s.active, tenant)
+	}
+	s.queues[tenant] = append(q, job)
+	s.cv.Signal()
+}
+
+// Done is closed once every worker has exited after ctx is done.
+func (s *FairScheduler[K]) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *FairScheduler[K]) work(ctx context.Context) {
+	for {
+		job, ok := s.next(ctx)
+		if !ok {
+			return
+		}
+		job()
+	}
+}
+
+// next takes the first job of the tenant at the head of the rotation and
+// moves that tenant to the back if it has more work.
+func (s *FairScheduler[K]) next(ctx context.Context) (func(), bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.active) == 0 && ctx.Err() == nil {
+		_ = s.cv.Wait(ctx)
+	}
+	if ctx.Err() != nil {
+		s.stopped = true
+		clear(s.queues)
+		s.active = nil
+		return nil, false
+	}
+
+	tenant := s.active[0]
+	s.active = s.active[1:]
+	q := s.queues[tenant]
+	job := q[0]
+	if len(q) == 1 {
+		delete(s.queues, tenant)
+	} else {
+		s.queues[tenant] = q[1:]
+		s.active = append(s.active, tenant)
+	}
+	return job, true
+}
@@ -0,0 +1,92 @@
+package examples
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFairSchedulerRoundRobin(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := NewFairScheduler[string](ctx, 1)
+
+	// Hold the only worker while the queues fill up.
+	gate := make(chan struct{})
+	s.Submit("gate", func() { <-gate })
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	submit := func(tenant string, n int) {
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			s.Submit(tenant, func() {
+				mu.Lock()
+				order = append(order, tenant)
+				mu.Unlock()
+				wg.Done()
+			})
+		}
+	}
+	submit("noisy", 30)
+	submit("a", 5)
+	submit("b", 5)
+	close(gate)
+	wg.Wait()
+
+	// In the first 15 jobs every tenant gets exactly 5 turns, even though
+	// noisy queued all 30 of its jobs first.
+	counts := make(map[string]int)
+	for _, tenant := range order[:15] {
+		counts[tenant]++
+	}
+	for _, tenant := range []string{"noisy", "a", "b"} {
+		if counts[tenant] != 5 {
+			t.Errorf("expected 5 of the first 15 jobs for %s, got %d (order %v)", tenant, counts[tenant], order[:15])
+		}
+	}
+	for _, tenant := range order[15:] {
+		if tenant != "noisy" {
+			t.Errorf("expected only noisy once a and b are drained, got %s", tenant)
+		}
+	}
+}
+
+func TestFairSchedulerSkipsEmptyTenants(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := NewFairScheduler[int](ctx, 2)
+
+	// A tenant whose queue drained must not hold up another.
+	done := make(chan struct{})
+	s.Submit(1, func() {})
+	time.Sleep(10 * time.Millisecond)
+	s.Submit(2, func() { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("job for tenant 2 did not run")
+	}
+}
+
+func TestFairSchedulerStops(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewFairScheduler[string](ctx, 3)
+	cancel()
+
+	select {
+	case <-s.Done():
+	case <-time.After(time.Second):
+		t.Fatal("workers did not exit after cancellation")
+	}
+
+	ran := false
+	s.Submit("late", func() { ran = true })
+	time.Sleep(10 * time.Millisecond)
+	if ran {
+		t.Error("expected jobs submitted after shutdown to be ignored")
+	}
+}
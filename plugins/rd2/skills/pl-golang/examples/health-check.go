@@ -0,0 +1,126 @@
+package examples
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HealthCheck probes one dependency, returning nil when it is healthy.
+type HealthCheck func(ctx context.Context) error
+
+// HealthReport is the outcome of running every registered check.
+type HealthReport struct {
+	Healthy bool
+	Checks  map[string]error // nil value means the check passed
+}
+
+// HealthChecker runs named checks concurrently under a shared timeout and
+// serves the aggregate as an HTTP health endpoint.
+type HealthChecker struct {
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	checks map[string]HealthCheck
+}
+
+// NewHealthChecker returns a HealthChecker whose runs are cut off after
+// timeout.
+func NewHealthChecker(timeout time.Duration) *HealthChecker {
+	return &HealthChecker{timeout: timeout, checks: make(map[string]HealthCheck)}
+}
+
+// Register adds or replaces the check called name.
+func (h *HealthChecker) Register(name string, check HealthCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = check
+}
+
+// Check runs all checks concurrently. A check still running when the timeout
+// expires is reported as failed with the context's error; Check does not
+// wait for it to return.
+func (h *HealthChecker) Check(ctx context.Context) HealthReport {
+	h.mu.RLock()
+	checks := make(map[string]HealthCheck, len(h.checks))
+	for name, c := range h.checks {
+		checks[name] = c
+	}
+	h.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result, len(checks)) // buffered so late checks never block
+	for name, check := range checks {
+		go func() { results <- result{name, check(ctx)} }()
+	}
+
+	report := HealthReport{Healthy: true, Checks: make(map[string]error, len(checks))}
+	for len(report.Checks) < len(checks) {
+		// Take results that are already in before looking at ctx, so a check
+		// that finished in time is never reported as timed out.
+		select {
+		case r := <-results:
+			report.Checks[r.name] = r.err
+			continue
+		default:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case r := <-results:
+			report.Checks[r.name] = r.err
+		case <-ctx.Done():
+		}
+	}
+	for name := range checks {
+		if _, done := report.Checks[name]; !done {
+			report.Checks[name] = fmt.Errorf("timed out: %w", ctx.Err())
+		}
+	}
+	for _, err := range report.Checks {
+		if err != nil {
+			report.Healthy = false
+		}
+	}
+	return report
+}
+
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// ServeHTTP runs the checks and responds 200 when all pass or 503 otherwise,
+// with a JSON body giving each check's status. Error text is included, so
+// mount it on an internal port if that is sensitive.
+func (h *HealthChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := h.Check(r.Context())
+
+	resp := healthResponse{Status: "ok", Checks: make(map[string]string, len(report.Checks))}
+	status := http.StatusOK
+	if !report.Healthy {
+		resp.Status = "unavailable"
+		status = http.StatusServiceUnavailable
+	}
+	for name, err := range report.Checks {
+		if err != nil {
+			resp.Checks[name] = err.Error()
+		} else {
+			resp.Checks[name] = "ok"
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,90 @@
+package examples
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func healthy(context.Context) error { return nil }
+
+func serveHealth(t *testing.T, h *HealthChecker) (int, healthResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	var body healthResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("expected JSON body: %v", err)
+	}
+	return rec.Code, body
+}
+
+func TestHealthCheckerAllHealthy(t *testing.T) {
+	h := NewHealthChecker(time.Second)
+	h.Register("db", healthy)
+	h.Register("cache", healthy)
+
+	code, body := serveHealth(t, h)
+	if code != http.StatusOK || body.Status != "ok" {
+		t.Errorf("expected 200 ok, got %d %q", code, body.Status)
+	}
+	for _, name := range []string{"db", "cache"} {
+		if body.Checks[name] != "ok" {
+			t.Errorf("expected %s ok, got %q", name, body.Checks[name])
+		}
+	}
+}
+
+func TestHealthCheckerOneFailing(t *testing.T) {
+	h := NewHealthChecker(time.Second)
+	h.Register("db", healthy)
+	h.Register("queue", func(context.Context) error { return errors.New("broker unreachable") })
+
+	code, body := serveHealth(t, h)
+	if code != http.StatusServiceUnavailable || body.Status != "unavailable" {
+		t.Errorf("expected 503 unavailable, got %d %q", code, body.Status)
+	}
+	if body.Checks["queue"] != "broker unreachable" {
+		t.Errorf("expected failing check named with its error, got %q", body.Checks["queue"])
+	}
+	if body.Checks["db"] != "ok" {
+		t.Errorf("expected db ok, got %q", body.Checks["db"])
+	}
+}
+
+func TestHealthCheckerTimeout(t *testing.T) {
+	h := NewHealthChecker(20 * time.Millisecond)
+	h.Register("db", healthy)
+	release := make(chan struct{})
+	defer close(release)
+	h.Register("stuck", func(context.Context) error {
+		<-release // ignores its context
+		return nil
+	})
+
+	start := time.Now()
+	report := h.Check(context.Background())
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected Check to return at the timeout, took %v", elapsed)
+	}
+	if report.Healthy {
+		t.Error("expected report to be unhealthy")
+	}
+	if err := report.Checks["stuck"]; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected stuck check to time out, got %v", err)
+	}
+	if err := report.Checks["db"]; err != nil {
+		t.Errorf("expected db healthy, got %v", err)
+	}
+
+	_, body := serveHealth(t, h)
+	if !strings.Contains(body.Checks["stuck"], "timed out") {
+		t.Errorf("expected timeout in response, got %q", body.Checks["stuck"])
+	}
+}
@@ -0,0 +1,101 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoCandidates is returned by FirstSuccess when called without functions.
+var ErrNoCandidates = errors.New("no candidates")
+
+// FirstSuccess runs every fn concurrently and returns the first successful
+// result, canceling the context passed to the others. If all fail, it returns
+// their errors joined. This models hedged requests against replicas.
+func FirstSuccess[T any](ctx context.Context, fns ...func(context.Context) (T, error)) (T, error) {
+	var zero T
+	if len(fns) == 0 {
+		return zero, ErrNoCandidates
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		v   T
+		err error
+	}
+	// Buffered so losers can finish after we return without leaking.
+	results := make(chan result, len(fns))
+	for _, fn := range fns {
+		go func() {
+			v, err := fn(ctx)
+			results <- result{v, err}
+		}()
+	}
+
+	errs := make([]error, 0, len(fns))
+	for range fns {
+		r := <-results
+		if r.err == nil {
+			return r.v, nil
+		}
+		errs = append(errs, r.err)
+	}
+	return zero, errors.Join(errs...)
+}
+
+// RetryHedged calls fn and, if it has not returned within hedgeAfter, starts
+// a second concurrent attempt, returning whichever succeeds first and
+// canceling the other. If the first attempt fails before the hedge is due,
+// the second attempt starts right away instead. If both fail, their errors
+// are joined. Hedging trims tail latency when one replica is slow, at the
+// cost of occasionally doing the work twice, so fn must be safe to repeat.
+func RetryHedged[T any](ctx context.Context, hedgeAfter time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	return retryHedgedClock(ctx, RealClock, hedgeAfter, fn)
+}
+
+func retryHedgedClock[T any](ctx context.Context, clock Clock, hedgeAfter time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		v   T
+		err error
+	}
+	// Buffered so the loser can finish after we return without leaking.
+	results := make(chan result, 2)
+	attempt := func() {
+		go func() {
+			v, err := fn(ctx)
+			results <- result{v, err}
+		}()
+	}
+
+	attempt()
+	running, hedged := 1, false
+	timer := clock.NewTimer(hedgeAfter)
+	defer timer.Stop()
+	hedge := timer.C()
+
+	var errs []error
+	for running > 0 {
+		select {
+		case r := <-results:
+			running--
+			if r.err == nil {
+				return r.v, nil
+			}
+			errs = append(errs, r.err)
+			if hedged || ctx.Err() != nil {
+				continue
+			}
+		case <-hedge:
+		}
+		attempt()
+		running++
+		hedged, hedge = true, nil
+	}
+	var zero T
+	return zero, errors.Join(errs...)
+}
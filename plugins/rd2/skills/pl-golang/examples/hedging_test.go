@@ -0,0 +1,150 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFirstSuccessCancelsOthers(t *testing.T) {
+	canceled := make(chan struct{}, 2)
+	slow := func(ctx context.Context) (string, error) {
+		select {
+		case <-ctx.Done():
+			canceled <- struct{}{}
+			return "", ctx.Err()
+		case <-time.After(5 * time.Second):
+			return "slow", nil
+		}
+	}
+	fast := func(context.Context) (string, error) {
+		return "fast", nil
+	}
+
+	start := time.Now()
+	got, err := FirstSuccess(context.Background(), slow, fast, slow)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "fast" {
+		t.Errorf("expected fast, got %q", got)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the fast result without waiting on slow replicas, took %v", elapsed)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-canceled:
+		case <-time.After(time.Second):
+			t.Fatal("expected slow candidates to be canceled")
+		}
+	}
+}
+
+func TestFirstSuccessSkipsFailures(t *testing.T) {
+	failFast := func(context.Context) (int, error) { return 0, errors.New("replica a down") }
+	succeedLater := func(context.Context) (int, error) {
+		time.Sleep(10 * time.Millisecond)
+		return 42, nil
+	}
+
+	got, err := FirstSuccess(context.Background(), failFast, succeedLater)
+	if err != nil || got != 42 {
+		t.Errorf("expected (42, nil), got (%d, %v)", got, err)
+	}
+}
+
+func TestFirstSuccessAllFail(t *testing.T) {
+	errA := errors.New("replica a down")
+	errB := errors.New("replica b down")
+
+	_, err := FirstSuccess(context.Background(),
+		func(context.Context) (int, error) { return 0, errA },
+		func(context.Context) (int, error) { return 0, errB },
+	)
+
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("expected joined errors, got %v", err)
+	}
+}
+
+func TestFirstSuccessNoCandidates(t *testing.T) {
+	if _, err := FirstSuccess[int](context.Background()); !errors.Is(err, ErrNoCandidates) {
+		t.Errorf("expected ErrNoCandidates, got %v", err)
+	}
+}
+
+func TestRetryHedgedHedgeWins(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	var calls atomic.Int32
+	canceled := make(chan struct{})
+	fn := func(ctx context.Context) (string, error) {
+		if calls.Add(1) == 1 {
+			<-ctx.Done()
+			close(canceled)
+			return "", ctx.Err()
+		}
+		return "hedge", nil
+	}
+
+	result := make(chan string, 1)
+	go func() {
+		v, err := retryHedgedClock(context.Background(), clock, 50*time.Millisecond, fn)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		result <- v
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(50 * time.Millisecond)
+
+	if got := <-result; got != "hedge" {
+		t.Errorf("expected hedge result, got %q", got)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("expected slow first attempt to be canceled")
+	}
+}
+
+func TestRetryHedgedFirstWins(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	var calls atomic.Int32
+	fn := func(context.Context) (int, error) {
+		calls.Add(1)
+		return 7, nil
+	}
+
+	got, err := retryHedgedClock(context.Background(), clock, time.Second, fn)
+	if err != nil || got != 7 {
+		t.Fatalf("expected (7, nil), got (%d, %v)", got, err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected no hedge, got %d calls", n)
+	}
+	if n := clock.Pending(); n != 0 {
+		t.Errorf("expected hedge timer stopped, %d pending", n)
+	}
+}
+
+func TestRetryHedgedBothFail(t *testing.T) {
+	errA := errors.New("replica a down")
+	errB := errors.New("replica b down")
+	var calls atomic.Int32
+	fn := func(context.Context) (int, error) {
+		if calls.Add(1) == 1 {
+			return 0, errA
+		}
+		return 0, errB
+	}
+
+	// The first failure triggers the hedge without waiting for the timer.
+	_, err := retryHedgedClock(context.Background(), NewFakeClock(time.Unix(0, 0)), time.Hour, fn)
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("expected both errors joined, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package examples
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// peakTracker records the highest number of callers seen between enter and
+// the exit it returns at the same time. The zero value is ready to use.
+type peakTracker struct {
+	running, peak atomic.Int64
+}
+
+// enter records one more concurrent caller and returns the func that
+// records it leaving.
+func (p *peakTracker) enter() (exit func()) {
+	cur := p.running.Add(1)
+	for {
+		old := p.peak.Load()
+		if cur <= old || p.peak.CompareAndSwap(old, cur) {
+			break
+		}
+	}
+	return func() { p.running.Add(-1) }
+}
+
+// Peak returns the highest concurrency recorded so far.
+func (p *peakTracker) Peak() int64 { return p.peak.Load() }
+
+// assertNoGoroutineLeak waits briefly for the goroutine count to settle back
+// to baseline and fails t if it does not.
+func assertNoGoroutineLeak(t *testing.T, baseline int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if runtime.NumGoroutine() <= baseline {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("goroutine leak: have %d, want <= %d", runtime.NumGoroutine(), baseline)
+}
@@ -0,0 +1,84 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// StatusError reports a non-2xx HTTP response.
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return "unexpected status: " + e.Status
+}
+
+// permanentError marks a failure that no retry can fix, such as a malformed
+// URL.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// GetWithRetry fetches url and returns its body, retrying transient failures
+// (transport errors and 5xx responses) with cfg's backoff. 4xx responses are
+// returned at once as a *StatusError since repeating them cannot help. Each
+// attempt is bounded by client.Timeout; ctx bounds the whole operation,
+// backoff included. Only use it for idempotent requests.
+func GetWithRetry(ctx context.Context, client *http.Client, url string, cfg BackoffConfig) ([]byte, error) {
+	var body []byte
+	var permanent error
+	err := Retry(ctx, cfg, func() error {
+		b, err := getOnce(ctx, client, url)
+		if err == nil {
+			body = b
+			return nil
+		}
+		var se *StatusError
+		var pe *permanentError
+		switch {
+		case errors.As(err, &pe):
+			permanent = pe.err
+			return nil // stop retrying; reported below
+		case (errors.As(err, &se) && se.StatusCode < 500) || ctx.Err() != nil:
+			permanent = err
+			return nil
+		}
+		return err
+	})
+	if permanent != nil {
+		return nil, permanent
+	}
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+func getOnce(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, &permanentError{fmt.Errorf("build request: %w", err)}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Drain so the connection can be reused for the next attempt.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read body: %w", err)
+	}
+	return body, nil
+}
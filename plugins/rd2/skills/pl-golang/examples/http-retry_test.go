@@ -0,0 +1,119 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var fastBackoff = BackoffConfig{MaxAttempts: 5, Initial: time.Millisecond, Max: 5 * time.Millisecond}
+
+func TestGetWithRetryRecoversFrom503(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer srv.Close()
+
+	body, err := GetWithRetry(context.Background(), srv.Client(), srv.URL, fastBackoff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != "hello" {
+		t.Errorf("expected body hello, got %q", body)
+	}
+	if n := hits.Load(); n != 3 {
+		t.Errorf("expected 3 attempts, got %d", n)
+	}
+}
+
+func TestGetWithRetryDoesNotRetry4xx(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	_, err := GetWithRetry(context.Background(), srv.Client(), srv.URL, fastBackoff)
+	var se *StatusError
+	if !errors.As(err, &se) || se.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 StatusError, got %v", err)
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("expected 1 attempt, got %d", n)
+	}
+}
+
+func TestGetWithRetryDoesNotRetryBadURL(t *testing.T) {
+	ctx := WithRetryBudget(context.Background(), 10)
+	_, err := GetWithRetry(ctx, http.DefaultClient, "http://[::1", fastBackoff)
+	if err == nil {
+		t.Fatal("expected an error for a malformed URL")
+	}
+	if b, _ := RetryBudgetFrom(ctx); b.Remaining() != 10 {
+		t.Errorf("expected a single attempt, %d retries spent", 10-b.Remaining())
+	}
+}
+
+func TestGetWithRetryExhausted(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	cfg := fastBackoff
+	cfg.MaxAttempts = 2
+	_, err := GetWithRetry(context.Background(), srv.Client(), srv.URL, cfg)
+	var se *StatusError
+	if !errors.As(err, &se) || se.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected 502 StatusError, got %v", err)
+	}
+}
+
+func TestGetWithRetryContextCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := GetWithRetry(ctx, srv.Client(), srv.URL, fastBackoff)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestGetWithRetryAttemptTimeout(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := srv.Client()
+	client.Timeout = 20 * time.Millisecond
+	body, err := GetWithRetry(context.Background(), client, srv.URL, fastBackoff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != "ok" {
+		t.Errorf("expected body ok, got %q", body)
+	}
+}
@@ -0,0 +1,113 @@
+package examples
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type idempotencyKeyCtx struct{}
+
+// WithIdempotencyKey returns a child context carrying key, so retries of a
+// mutating operation can be recognized downstream.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+// IdempotencyKey returns the key stored by WithIdempotencyKey.
+func IdempotencyKey(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyCtx{}).(string)
+	return key, ok && key != ""
+}
+
+type idempotencyEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// IdempotencyStore remembers the results of completed operations by
+// idempotency key for a TTL, so a retried request gets the original result
+// instead of executing twice. Failed operations are not recorded and may be
+// retried. Expired entries are swept on writes at most once per TTL, so
+// memory stays bounded by the keys completed within roughly the last two
+// TTLs.
+type IdempotencyStore[V any] struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	clock     Clock
+	entries   map[string]idempotencyEntry[V]
+	lastSweep time.Time
+}
+
+// NewIdempotencyStore returns a store that keeps each result for ttl after it
+// completed. A nil clock means RealClock.
+func NewIdempotencyStore[V any](ttl time.Duration, clock Clock) *IdempotencyStore[V] {
+	if clock == nil {
+		clock = RealClock
+	}
+	return &IdempotencyStore[V]{
+		ttl:       ttl,
+		clock:     clock,
+		entries:   make(map[string]idempotencyEntry[V]),
+		lastSweep: clock.Now(),
+	}
+}
+
+// Do returns the stored result for the context's idempotency key, or runs fn
+// and stores its result on success. Without a key, fn always runs.
+//
+// Concurrent calls with the same key are not coalesced; pair the store with
+// a lock or single-flight if the operation itself must not overlap.
+func (s *IdempotencyStore[V]) Do(ctx context.Context, fn func(context.Context) (V, error)) (V, error) {
+	key, ok := IdempotencyKey(ctx)
+	if !ok {
+		return fn(ctx)
+	}
+
+	if v, found := s.lookup(key); found {
+		return v, nil
+	}
+
+	v, err := fn(ctx)
+	if err != nil {
+		return v, err
+	}
+
+	s.mu.Lock()
+	now := s.clock.Now()
+	s.sweep(now)
+	s.entries[key] = idempotencyEntry[V]{value: v, expires: now.Add(s.ttl)}
+	s.mu.Unlock()
+	return v, nil
+}
+
+// sweep drops expired entries if a TTL has passed since the last sweep.
+// s.mu must be held.
+func (s *IdempotencyStore[V]) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.ttl {
+		return
+	}
+	for k, e := range s.entries {
+		if !now.Before(e.expires) {
+			delete(s.entries, k)
+		}
+	}
+	s.lastSweep = now
+}
+
+func (s *IdempotencyStore[V]) lookup(key string) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if !s.clock.Now().Before(e.expires) {
+		delete(s.entries, key)
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
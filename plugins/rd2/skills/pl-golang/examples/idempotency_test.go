@@ -0,0 +1,134 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyKeyRoundTrip(t *testing.T) {
+	ctx := WithIdempotencyKey(context.Background(), "req-1")
+
+	key, ok := IdempotencyKey(ctx)
+	if !ok || key != "req-1" {
+		t.Errorf("expected (req-1, true), got (%q, %v)", key, ok)
+	}
+
+	if _, ok := IdempotencyKey(context.Background()); ok {
+		t.Error("expected no key on a bare context")
+	}
+}
+
+func TestIdempotencyStoreReturnsStoredResult(t *testing.T) {
+	store := NewIdempotencyStore[string](time.Minute, nil)
+	ctx := WithIdempotencyKey(context.Background(), "charge-7")
+
+	calls := 0
+	charge := func(context.Context) (string, error) {
+		calls++
+		return "receipt-1", nil
+	}
+
+	for i := 0; i < 3; i++ {
+		got, err := store.Do(ctx, charge)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "receipt-1" {
+			t.Errorf("expected receipt-1, got %q", got)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestIdempotencyStoreDoesNotCacheErrors(t *testing.T) {
+	store := NewIdempotencyStore[int](time.Minute, nil)
+	ctx := WithIdempotencyKey(context.Background(), "k")
+
+	calls := 0
+	fn := func(context.Context) (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, errors.New("transient")
+		}
+		return 42, nil
+	}
+
+	if _, err := store.Do(ctx, fn); err == nil {
+		t.Fatal("expected error on first call")
+	}
+	got, err := store.Do(ctx, fn)
+	if err != nil || got != 42 {
+		t.Errorf("expected (42, nil), got (%d, %v)", got, err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestIdempotencyStoreTTLExpiry(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	store := NewIdempotencyStore[int](time.Minute, clock)
+
+	calls := 0
+	fn := func(context.Context) (int, error) {
+		calls++
+		return calls, nil
+	}
+
+	a := WithIdempotencyKey(context.Background(), "a")
+	b := WithIdempotencyKey(context.Background(), "b")
+
+	_, _ = store.Do(a, fn)
+	clock.Advance(30 * time.Second)
+	_, _ = store.Do(b, fn)
+	clock.Advance(45 * time.Second)
+
+	// "a" expired after 60s; "b" still has 15s left.
+	if got, _ := store.Do(a, fn); got != 3 {
+		t.Errorf("expected expired key to re-execute (3), got %d", got)
+	}
+	if got, _ := store.Do(b, fn); got != 2 {
+		t.Errorf("expected live key to return stored result (2), got %d", got)
+	}
+}
+
+func TestIdempotencyStoreSweepsExpiredKeys(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	store := NewIdempotencyStore[int](time.Minute, clock)
+	fn := func(context.Context) (int, error) { return 1, nil }
+
+	for i := 0; i < 10; i++ {
+		_, _ = store.Do(WithIdempotencyKey(context.Background(), fmt.Sprint(i)), fn)
+	}
+	clock.Advance(time.Minute)
+	_, _ = store.Do(WithIdempotencyKey(context.Background(), "fresh"), fn)
+
+	store.mu.Lock()
+	n := len(store.entries)
+	store.mu.Unlock()
+	if n != 1 {
+		t.Errorf("expected expired keys to be swept, %d entries left", n)
+	}
+}
+
+func TestIdempotencyStoreWithoutKey(t *testing.T) {
+	store := NewIdempotencyStore[int](time.Minute, nil)
+
+	calls := 0
+	fn := func(context.Context) (int, error) {
+		calls++
+		return calls, nil
+	}
+
+	_, _ = store.Do(context.Background(), fn)
+	_, _ = store.Do(context.Background(), fn)
+	if calls != 2 {
+		t.Errorf("expected fn to run on every call without a key, got %d", calls)
+	}
+}
@@ -0,0 +1,105 @@
+package examples
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// KeyedRateLimiter keeps an independent token bucket per key, e.g. per API
+// client or remote IP. Buckets are created on first use and evicted after
+// idleTTL without requests, so memory stays bounded by the number of recently
+// active keys. idleTTL is raised to at least burst/rate, the time an idle
+// bucket takes to refill, so eviction never grants extra tokens.
+type KeyedRateLimiter[K comparable] struct {
+	rate    float64
+	burst   int
+	idleTTL time.Duration
+	clock   Clock
+
+	mu        sync.Mutex
+	buckets   map[K]*keyedBucket
+	lastSweep time.Time
+}
+
+type keyedBucket struct {
+	limiter  *RateLimiter
+	lastUsed time.Time
+	waiters  int // Wait calls in progress; such buckets are never evicted
+}
+
+// NewKeyedRateLimiter returns a limiter allowing each key rate requests per
+// second with the given burst. It panics if rate is not positive or burst
+// is less than 1.
+func NewKeyedRateLimiter[K comparable](rate float64, burst int, idleTTL time.Duration) *KeyedRateLimiter[K] {
+	return newKeyedRateLimiterClock[K](rate, burst, idleTTL, RealClock)
+}
+
+func newKeyedRateLimiterClock[K comparable](rate float64, burst int, idleTTL time.Duration, clock Clock) *KeyedRateLimiter[K] {
+	if !(rate > 0) || burst < 1 {
+		panic("examples: keyed rate limiter needs rate > 0 and burst >= 1")
+	}
+	idleTTL = max(idleTTL, time.Duration(float64(burst)/rate*float64(time.Second)))
+	return &KeyedRateLimiter[K]{
+		rate:      rate,
+		burst:     burst,
+		idleTTL:   idleTTL,
+		clock:     clock,
+		buckets:   make(map[K]*keyedBucket),
+		lastSweep: clock.Now(),
+	}
+}
+
+// Allow consumes a token from key's bucket if one is available, without
+// blocking.
+func (l *KeyedRateLimiter[K]) Allow(key K) bool {
+	l.mu.Lock()
+	b := l.bucket(key)
+	l.mu.Unlock()
+	return b.limiter.Allow()
+}
+
+// Wait blocks until key's bucket has a token or ctx is done.
+func (l *KeyedRateLimiter[K]) Wait(ctx context.Context, key K) error {
+	l.mu.Lock()
+	b := l.bucket(key)
+	b.waiters++
+	l.mu.Unlock()
+
+	defer func() {
+		l.mu.Lock()
+		b.waiters--
+		b.lastUsed = l.clock.Now()
+		l.mu.Unlock()
+	}()
+	return b.limiter.Wait(ctx)
+}
+
+// Len returns the number of live buckets.
+func (l *KeyedRateLimiter[K]) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+// bucket returns key's bucket, creating it if needed, and evicts idle
+// buckets at most once per idleTTL. l.mu must be held.
+func (l *KeyedRateLimiter[K]) bucket(key K) *keyedBucket {
+	now := l.clock.Now()
+	if now.Sub(l.lastSweep) >= l.idleTTL {
+		for k, b := range l.buckets {
+			if b.waiters == 0 && now.Sub(b.lastUsed) >= l.idleTTL {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &keyedBucket{limiter: newRateLimiterClock(l.rate, l.burst, l.clock)}
+		l.buckets[key] = b
+	}
+	b.lastUsed = now
+	return b
+}
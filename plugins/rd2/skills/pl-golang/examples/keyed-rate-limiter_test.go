@@ -0,0 +1,105 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestKeyedRateLimiterIndependentKeys(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	l := newKeyedRateLimiterClock[string](1, 2, time.Minute, clock)
+
+	for i := 0; i < 2; i++ {
+		if !l.Allow("alice") {
+			t.Fatalf("expected alice request %d within burst to be allowed", i)
+		}
+	}
+	if l.Allow("alice") {
+		t.Error("expected alice to be limited after her burst")
+	}
+	if !l.Allow("bob") {
+		t.Error("expected bob to be unaffected by alice's usage")
+	}
+}
+
+func TestKeyedRateLimiterRecovers(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	l := newKeyedRateLimiterClock[string](2, 1, time.Minute, clock)
+
+	if !l.Allow("10.0.0.1") || l.Allow("10.0.0.1") {
+		t.Fatal("expected exactly one request within burst")
+	}
+	clock.Advance(500 * time.Millisecond)
+	if !l.Allow("10.0.0.1") {
+		t.Error("expected key to recover after refill interval")
+	}
+}
+
+func TestKeyedRateLimiterWait(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	l := newKeyedRateLimiterClock[string](1, 1, time.Minute, clock)
+	_ = l.Allow("alice")
+
+	done := make(chan error, 1)
+	go func() { done <- l.Wait(context.Background(), "alice") }()
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { done <- l.Wait(ctx, "alice") }()
+	clock.BlockUntil(1)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Canceled, got %v", err)
+	}
+}
+
+func TestKeyedRateLimiterEvictsIdle(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	l := newKeyedRateLimiterClock[string](1, 1, time.Minute, clock)
+
+	l.Allow("a")
+	l.Allow("b")
+	if n := l.Len(); n != 2 {
+		t.Fatalf("expected 2 buckets, got %d", n)
+	}
+
+	clock.Advance(30 * time.Second)
+	l.Allow("b") // keeps b fresh
+
+	clock.Advance(45 * time.Second)
+	l.Allow("c") // triggers a sweep: a idle 75s, b idle 45s
+	if n := l.Len(); n != 2 {
+		t.Errorf("expected 2 buckets after the sweep, got %d", n)
+	}
+	if _, ok := l.buckets["a"]; ok {
+		t.Error("expected idle bucket a to be evicted")
+	}
+}
+
+func TestKeyedRateLimiterZeroIdleTTL(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	l := newKeyedRateLimiterClock[string](1, 1, 0, clock)
+
+	if !l.Allow("alice") {
+		t.Fatal("expected first request to be allowed")
+	}
+	clock.Advance(time.Millisecond)
+	if l.Allow("alice") {
+		t.Error("expected a zero idleTTL not to evict the bucket and refill it early")
+	}
+}
+
+func TestNewKeyedRateLimiterInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for zero rate")
+		}
+	}()
+	NewKeyedRateLimiter[string](0, 1, time.Minute)
+}
@@ -0,0 +1,71 @@
+package examples
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+// LatencyTracker smooths observed latencies into an exponential moving
+// average and estimates the 99th percentile from a fixed-size uniform
+// sample (reservoir sampling), so memory stays bounded however many
+// observations arrive. It is safe for concurrent use.
+type LatencyTracker struct {
+	alpha float64
+
+	mu        sync.Mutex
+	ema       float64 // nanoseconds
+	count     int64
+	reservoir []time.Duration
+}
+
+// NewLatencyTracker returns a tracker whose EMA gives each new observation
+// weight alpha (0 < alpha <= 1; higher reacts faster) and whose percentile
+// estimate keeps at most size samples (at least 1).
+func NewLatencyTracker(alpha float64, size int) *LatencyTracker {
+	if alpha <= 0 || alpha > 1 {
+		panic("examples: latency tracker alpha must be in (0, 1]")
+	}
+	return &LatencyTracker{alpha: alpha, reservoir: make([]time.Duration, 0, max(size, 1))}
+}
+
+// Observe records one latency.
+func (t *LatencyTracker) Observe(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.count++
+	if t.count == 1 {
+		t.ema = float64(d)
+	} else {
+		t.ema += t.alpha * (float64(d) - t.ema)
+	}
+
+	if len(t.reservoir) < cap(t.reservoir) {
+		t.reservoir = append(t.reservoir, d)
+	} else if i := rand.Int64N(t.count); i < int64(len(t.reservoir)) {
+		// Keeps every observation so far in the sample with equal odds.
+		t.reservoir[i] = d
+	}
+}
+
+// EMA returns the exponential moving average, or 0 before any observation.
+func (t *LatencyTracker) EMA() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return time.Duration(t.ema)
+}
+
+// P99 returns the estimated 99th percentile latency, or 0 before any
+// observation.
+func (t *LatencyTracker) P99() time.Duration {
+	t.mu.Lock()
+	sample := slices.Clone(t.reservoir)
+	t.mu.Unlock()
+	if len(sample) == 0 {
+		return 0
+	}
+	slices.Sort(sample)
+	return sample[int(math.Ceil(0.99*float64(len(sample))))-1]
+}
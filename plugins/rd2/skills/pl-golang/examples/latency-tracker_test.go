@@ -0,0 +1,61 @@
+package examples
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLatencyTrackerEMA(t *testing.T) {
+	tr := NewLatencyTracker(0.2, 100)
+	if tr.EMA() != 0 || tr.P99() != 0 {
+		t.Fatalf("expected zero before observations, got EMA %v, P99 %v", tr.EMA(), tr.P99())
+	}
+
+	for i := 0; i < 50; i++ {
+		tr.Observe(10 * time.Millisecond)
+	}
+	if got := tr.EMA(); got != 10*time.Millisecond {
+		t.Errorf("expected EMA 10ms for a steady stream, got %v", got)
+	}
+
+	// A latency shift pulls the average over gradually, not at once.
+	tr.Observe(110 * time.Millisecond)
+	if got := tr.EMA(); got != 30*time.Millisecond {
+		t.Errorf("expected EMA 30ms after one slow call, got %v", got)
+	}
+	for i := 0; i < 50; i++ {
+		tr.Observe(110 * time.Millisecond)
+	}
+	if got := tr.EMA(); got < 109*time.Millisecond || got > 110*time.Millisecond {
+		t.Errorf("expected EMA to converge on 110ms, got %v", got)
+	}
+}
+
+func TestLatencyTrackerP99Concurrent(t *testing.T) {
+	tr := NewLatencyTracker(0.1, 1000)
+
+	// 5% of calls are slow, so the 99th percentile lies in the tail.
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				d := 5 * time.Millisecond
+				if i%20 == 0 {
+					d = time.Second
+				}
+				tr.Observe(d)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := tr.P99(); got != time.Second {
+		t.Errorf("expected P99 in the 1s tail, got %v", got)
+	}
+	if got := tr.EMA(); got < 5*time.Millisecond || got > time.Second {
+		t.Errorf("expected EMA between the fast and slow latencies, got %v", got)
+	}
+}
@@ -0,0 +1,105 @@
+package examples
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Lease is exclusive, time-bounded ownership of a key granted by a
+// LeaseManager.
+type Lease[K comparable] struct {
+	Key     K
+	Expires time.Time
+	token   uint64
+}
+
+// LeaseManager grants exclusive leases per key. A lease ends when released or
+// when its TTL passes, whichever is first, so a holder that crashes or hangs
+// cannot keep a key locked forever. Holders must finish before Expires;
+// after that another caller may be granted the key. Expired leases that were
+// never released are swept as new ones are granted, so memory stays
+// proportional to the number of live leases.
+type LeaseManager[K comparable] struct {
+	clock Clock
+
+	mu      sync.Mutex
+	next    uint64
+	leases  map[K]*leaseState
+	sweepAt int // sweep expired leases once len(leases) reaches this
+}
+
+// minLeaseSweep is the smallest table size that triggers a sweep.
+const minLeaseSweep = 64
+
+type leaseState struct {
+	token    uint64
+	expires  time.Time
+	released chan struct{}
+}
+
+// NewLeaseManager returns an empty LeaseManager.
+func NewLeaseManager[K comparable]() *LeaseManager[K] {
+	return newLeaseManagerClock[K](RealClock)
+}
+
+func newLeaseManagerClock[K comparable](clock Clock) *LeaseManager[K] {
+	return &LeaseManager[K]{clock: clock, leases: make(map[K]*leaseState), sweepAt: minLeaseSweep}
+}
+
+// Acquire grants a lease on key for ttl, blocking while another lease on key
+// is live until it is released, it expires, or ctx is done.
+func (m *LeaseManager[K]) Acquire(ctx context.Context, key K, ttl time.Duration) (Lease[K], error) {
+	for {
+		m.mu.Lock()
+		now := m.clock.Now()
+		st, held := m.leases[key]
+		if !held || !now.Before(st.expires) {
+			if len(m.leases) >= m.sweepAt {
+				m.sweep(now)
+			}
+			m.next++
+			st = &leaseState{token: m.next, expires: now.Add(ttl), released: make(chan struct{})}
+			m.leases[key] = st
+			m.mu.Unlock()
+			return Lease[K]{Key: key, Expires: st.expires, token: st.token}, nil
+		}
+		released, wait := st.released, st.expires.Sub(now)
+		m.mu.Unlock()
+
+		timer := m.clock.NewTimer(wait)
+		select {
+		case <-released:
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return Lease[K]{}, ctx.Err()
+		}
+		timer.Stop()
+	}
+}
+
+// Release ends lease early so waiters can take the key. Releasing a lease
+// twice, or after it expired and the key was granted again, has no effect.
+func (m *LeaseManager[K]) Release(lease Lease[K]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st, ok := m.leases[lease.Key]
+	if !ok || st.token != lease.token {
+		return
+	}
+	delete(m.leases, lease.Key)
+	close(st.released)
+}
+
+// sweep drops expired leases, waking anyone still waiting on them, and sets
+// the next sweep for when the table has doubled. m.mu must be held.
+func (m *LeaseManager[K]) sweep(now time.Time) {
+	for k, st := range m.leases {
+		if !now.Before(st.expires) {
+			delete(m.leases, k)
+			close(st.released)
+		}
+	}
+	m.sweepAt = max(2*len(m.leases), minLeaseSweep)
+}
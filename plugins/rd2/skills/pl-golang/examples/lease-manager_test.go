@@ -0,0 +1,139 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLeaseManagerBlocksUntilRelease(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	m := newLeaseManagerClock[string](clock)
+
+	first, err := m.Acquire(context.Background(), "job-1", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	acquired := make(chan Lease[string], 1)
+	go func() {
+		l, err := m.Acquire(context.Background(), "job-1", time.Minute)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		acquired <- l
+	}()
+
+	clock.BlockUntil(1)
+	select {
+	case <-acquired:
+		t.Fatal("expected second Acquire to block while the lease is held")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// Other keys are independent.
+	if _, err := m.Acquire(context.Background(), "job-2", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m.Release(first)
+	select {
+	case l := <-acquired:
+		if l.Key != "job-1" {
+			t.Errorf("expected lease on job-1, got %q", l.Key)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected waiter to acquire after release")
+	}
+}
+
+func TestLeaseManagerAcquireCanceled(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	m := newLeaseManagerClock[string](clock)
+	if _, err := m.Acquire(context.Background(), "k", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := m.Acquire(ctx, "k", time.Minute)
+		errs <- err
+	}()
+	clock.BlockUntil(1)
+	cancel()
+
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Canceled, got %v", err)
+	}
+	if n := clock.Pending(); n != 0 {
+		t.Errorf("expected wait timer to be stopped, %d pending", n)
+	}
+}
+
+func TestLeaseManagerExpiry(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	m := newLeaseManagerClock[string](clock)
+	crashed, err := m.Acquire(context.Background(), "k", 30*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	acquired := make(chan Lease[string], 1)
+	go func() {
+		l, _ := m.Acquire(context.Background(), "k", time.Minute)
+		acquired <- l
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(30 * time.Second)
+
+	var next Lease[string]
+	select {
+	case next = <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("expected expired lease to free the key")
+	}
+
+	// The stale holder releasing late must not free the new lease.
+	m.Release(crashed)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := m.Acquire(ctx, "k", time.Minute); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected key to stay held by the new lease, got %v", err)
+	}
+	m.Release(next)
+}
+
+func TestLeaseManagerReleaseIdempotent(t *testing.T) {
+	m := newLeaseManagerClock[int](NewFakeClock(time.Unix(0, 0)))
+	l, err := m.Acquire(context.Background(), 1, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.Release(l)
+	m.Release(l)
+
+	if _, err := m.Acquire(context.Background(), 1, time.Minute); err != nil {
+		t.Fatalf("expected key free after release, got %v", err)
+	}
+}
+
+func TestLeaseManagerSweepsExpiredLeases(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	m := newLeaseManagerClock[int](clock)
+
+	for i := 0; i < 10*minLeaseSweep; i++ {
+		if _, err := m.Acquire(context.Background(), i, time.Second); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		clock.Advance(time.Second)
+	}
+
+	m.mu.Lock()
+	n := len(m.leases)
+	m.mu.Unlock()
+	if n > minLeaseSweep {
+		t.Errorf("expected expired leases to be swept, %d left", n)
+	}
+}
@@ -0,0 +1,42 @@
+package examples
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// LimitConcurrency caps in-flight requests through the wrapped handler at
+// limit. Requests over the limit are rejected immediately with 503.
+func LimitConcurrency(limit int) Middleware {
+	return LimitConcurrencyWait(limit, 0)
+}
+
+// LimitConcurrencyWait is LimitConcurrency with queueing: a request over the
+// limit waits up to wait for a slot before being rejected with 503. Waiting
+// also ends if the client goes away. A non-positive wait rejects immediately.
+func LimitConcurrencyWait(limit int, wait time.Duration) Middleware {
+	sem := NewSemaphore(limit)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !acquireSlot(r.Context(), sem, wait) {
+				WriteError(w, NewCoded(CodeUnavailable, "server busy"))
+				return
+			}
+			defer sem.Release()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func acquireSlot(ctx context.Context, sem *Semaphore, wait time.Duration) bool {
+	if sem.TryAcquire() {
+		return true
+	}
+	if wait <= 0 {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+	return sem.Acquire(ctx) == nil
+}
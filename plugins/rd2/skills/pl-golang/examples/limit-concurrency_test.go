@@ -0,0 +1,110 @@
+package examples
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingHandler holds each request until release is closed, signaling
+// entered as each one starts.
+func blockingHandler(entered chan<- struct{}, release <-chan struct{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entered <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestLimitConcurrencyRejectsOverflow(t *testing.T) {
+	const max = 2
+	entered := make(chan struct{}, max)
+	release := make(chan struct{})
+	handler := LimitConcurrency(max)(blockingHandler(entered, release))
+
+	var wg sync.WaitGroup
+	codes := make(chan int, max)
+	for i := 0; i < max; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+			codes <- rec.Code
+		}()
+	}
+	for i := 0; i < max; i++ {
+		<-entered
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 for request over the limit, got %d", rec.Code)
+	}
+
+	close(release)
+	wg.Wait()
+	close(codes)
+	for code := range codes {
+		if code != http.StatusOK {
+			t.Errorf("expected 200 for admitted request, got %d", code)
+		}
+	}
+
+	// Slots are free again.
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 once slots free, got %d", rec.Code)
+	}
+}
+
+func TestLimitConcurrencyWaitQueues(t *testing.T) {
+	entered := make(chan struct{}, 2)
+	release := make(chan struct{})
+	handler := LimitConcurrencyWait(1, time.Second)(blockingHandler(entered, release))
+
+	first := make(chan int, 1)
+	go func() {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		first <- rec.Code
+	}()
+	<-entered
+
+	second := make(chan int, 1)
+	go func() {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		second <- rec.Code
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	if code := <-first; code != http.StatusOK {
+		t.Errorf("expected 200 for first request, got %d", code)
+	}
+	if code := <-second; code != http.StatusOK {
+		t.Errorf("expected queued request to proceed with 200, got %d", code)
+	}
+}
+
+func TestLimitConcurrencyWaitTimesOut(t *testing.T) {
+	entered := make(chan struct{}, 1)
+	release := make(chan struct{})
+	defer close(release)
+	handler := LimitConcurrencyWait(1, 20*time.Millisecond)(blockingHandler(entered, release))
+
+	go handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	<-entered
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 after queue timeout, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,82 @@
+package examples
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+// Keys returns m's keys in unspecified order. A nil or empty map yields an
+// empty, non-nil slice.
+func Keys[K comparable, V any](m map[K]V) []K {
+	out := make([]K, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
+
+// Values returns m's values in unspecified order. A nil or empty map yields
+// an empty, non-nil slice.
+func Values[K comparable, V any](m map[K]V) []V {
+	out := make([]V, 0, len(m))
+	for _, v := range m {
+		out = append(out, v)
+	}
+	return out
+}
+
+// SortedKeys returns m's keys in ascending order, for deterministic output
+// and stable tests.
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := Keys(m)
+	slices.Sort(keys)
+	return keys
+}
+
+// MapKeys returns a copy of m with each key replaced by fn(key). If fn maps
+// several keys to the same result, the last one iterated wins; since map
+// iteration order is unspecified, so is which value survives.
+func MapKeys[K1, K2 comparable, V any](m map[K1]V, fn func(K1) K2) map[K2]V {
+	out := make(map[K2]V, len(m))
+	for k, v := range m {
+		out[fn(k)] = v
+	}
+	return out
+}
+
+// MapValues returns a copy of m with each value replaced by fn(value).
+func MapValues[K comparable, V1, V2 any](m map[K]V1, fn func(V1) V2) map[K]V2 {
+	out := make(map[K]V2, len(m))
+	for k, v := range m {
+		out[k] = fn(v)
+	}
+	return out
+}
+
+// ForEachKeyConcurrent calls fn for every entry of m using at most workers
+// goroutines (workers <= 0 means one per entry). On the first error the
+// context passed to fn is canceled, no further entries are started, and that
+// error is returned once in-flight calls finish. If ctx is done before all
+// entries run, ctx.Err() is returned.
+//
+// m must not be modified while ForEachKeyConcurrent runs.
+func ForEachKeyConcurrent[K comparable, V any](ctx context.Context, m map[K]V, workers int, fn func(context.Context, K, V) error) error {
+	g, gctx := NewGroup(ctx, workers)
+	for k, v := range m {
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			// Go may have waited for a slot while another entry failed.
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			return fn(gctx, k, v)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
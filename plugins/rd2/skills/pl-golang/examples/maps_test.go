@@ -0,0 +1,209 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeysValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		m      map[string]int
+		keys   []string
+		values []int
+	}{
+		{"populated", map[string]int{"a": 1, "b": 2, "c": 3}, []string{"a", "b", "c"}, []int{1, 2, 3}},
+		{"empty", map[string]int{}, []string{}, []int{}},
+		{"nil", nil, []string{}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, values := Keys(tt.m), Values(tt.m)
+			if keys == nil || values == nil {
+				t.Fatal("expected non-nil slices")
+			}
+			slices.Sort(keys)
+			slices.Sort(values)
+			if !slices.Equal(keys, tt.keys) {
+				t.Errorf("Keys = %v, want %v", keys, tt.keys)
+			}
+			if !slices.Equal(values, tt.values) {
+				t.Errorf("Values = %v, want %v", values, tt.values)
+			}
+		})
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	ints := SortedKeys(map[int]bool{3: true, -1: true, 10: false, 0: true})
+	if want := []int{-1, 0, 3, 10}; !slices.Equal(ints, want) {
+		t.Errorf("SortedKeys = %v, want %v", ints, want)
+	}
+
+	words := SortedKeys(map[string]int{"pear": 2, "apple": 5, "fig": 1})
+	if want := []string{"apple", "fig", "pear"}; !slices.Equal(words, want) {
+		t.Errorf("SortedKeys = %v, want %v", words, want)
+	}
+
+	if empty := SortedKeys(map[string]int{}); len(empty) != 0 {
+		t.Errorf("expected no keys, got %v", empty)
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        map[string]int
+		expected map[string]int
+	}{
+		{"distinct keys", map[string]int{"a": 1, "b": 2}, map[string]int{"A": 1, "B": 2}},
+		{"empty", map[string]int{}, map[string]int{}},
+		{"nil", nil, map[string]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MapKeys(tt.m, strings.ToUpper); !maps.Equal(got, tt.expected) {
+				t.Errorf("MapKeys(%v) = %v, want %v", tt.m, got, tt.expected)
+			}
+		})
+	}
+
+	t.Run("collision keeps one value", func(t *testing.T) {
+		got := MapKeys(map[string]int{"a": 1, "A": 2}, strings.ToUpper)
+		if len(got) != 1 || (got["A"] != 1 && got["A"] != 2) {
+			t.Errorf("expected a single entry holding one of the colliding values, got %v", got)
+		}
+	})
+}
+
+func TestMapValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        map[string]int
+		expected map[string]string
+	}{
+		{"populated", map[string]int{"a": 1, "b": 22}, map[string]string{"a": "1", "b": "22"}},
+		{"empty", map[string]int{}, map[string]string{}},
+		{"nil", nil, map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MapValues(tt.m, strconv.Itoa); !maps.Equal(got, tt.expected) {
+				t.Errorf("MapValues(%v) = %v, want %v", tt.m, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestForEachKeyConcurrentVisitsEachOnce(t *testing.T) {
+	m := make(map[int]string)
+	for i := 0; i < 50; i++ {
+		m[i] = "v"
+	}
+
+	var mu sync.Mutex
+	visits := make(map[int]int)
+	err := ForEachKeyConcurrent(context.Background(), m, 4, func(_ context.Context, k int, v string) error {
+		mu.Lock()
+		visits[k]++
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(visits) != len(m) {
+		t.Errorf("expected %d keys visited, got %d", len(m), len(visits))
+	}
+	for k, n := range visits {
+		if n != 1 {
+			t.Errorf("key %d visited %d times", k, n)
+		}
+	}
+}
+
+func TestForEachKeyConcurrentBound(t *testing.T) {
+	m := make(map[int]int)
+	for i := 0; i < 20; i++ {
+		m[i] = i
+	}
+
+	const workers = 3
+	var peaks peakTracker
+	err := ForEachKeyConcurrent(context.Background(), m, workers, func(context.Context, int, int) error {
+		defer peaks.enter()()
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p := peaks.Peak(); p > workers {
+		t.Errorf("expected at most %d concurrent calls, saw %d", workers, p)
+	}
+}
+
+func TestForEachKeyConcurrentFirstError(t *testing.T) {
+	m := make(map[int]int)
+	for i := 0; i < 100; i++ {
+		m[i] = i
+	}
+
+	errBad := errors.New("bad entry")
+	var calls atomic.Int32
+	err := ForEachKeyConcurrent(context.Background(), m, 2, func(ctx context.Context, k, v int) error {
+		if calls.Add(1) == 1 {
+			return errBad
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+			return nil
+		}
+	})
+	if !errors.Is(err, errBad) {
+		t.Fatalf("expected errBad, got %v", err)
+	}
+	if n := calls.Load(); n >= int32(len(m)) {
+		t.Errorf("expected remaining entries to be skipped, %d calls made", n)
+	}
+}
+
+func TestForEachKeyConcurrentStartsNothingAfterFailure(t *testing.T) {
+	errBad := errors.New("bad entry")
+	calls := 0
+	err := ForEachKeyConcurrent(context.Background(), map[int]int{1: 1, 2: 2, 3: 3, 4: 4}, 1, func(context.Context, int, int) error {
+		calls++ // workers=1, so calls never overlap
+		return errBad
+	})
+	if !errors.Is(err, errBad) {
+		t.Fatalf("expected errBad, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected no entries to start after the first failure, got %d calls", calls)
+	}
+}
+
+func TestForEachKeyConcurrentParentCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := ForEachKeyConcurrent(ctx, map[string]int{"a": 1}, 1, func(context.Context, string, int) error {
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package examples
+
+import (
+	"errors"
+	"sync"
+)
+
+var errMemoPanicked = errors.New("memoized function panicked")
+
+type memoCall[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// Memoize wraps fn so each key is computed at most once while it keeps
+// succeeding. Successful results are cached forever; errors are not cached,
+// so the next call for that key retries. Concurrent calls for a key that is
+// already being computed wait for that call and share its result (a
+// single-flight), so a burst of identical requests runs fn once.
+//
+// The cache is unbounded; use TTLCache when entries must expire.
+func Memoize[K comparable, V any](fn func(K) (V, error)) func(K) (V, error) {
+	var mu sync.Mutex
+	calls := make(map[K]*memoCall[V])
+
+	return func(key K) (V, error) {
+		mu.Lock()
+		if c, ok := calls[key]; ok {
+			mu.Unlock()
+			<-c.done
+			return c.val, c.err
+		}
+		c := &memoCall[V]{done: make(chan struct{})}
+		calls[key] = c
+		mu.Unlock()
+
+		defer func() {
+			if c.err != nil {
+				mu.Lock()
+				delete(calls, key)
+				mu.Unlock()
+			}
+			close(c.done)
+		}()
+		// Until fn returns normally, treat the call as failed so a panic
+		// neither caches a zero value nor strands waiters.
+		c.err = errMemoPanicked
+		c.val, c.err = fn(key)
+		return c.val, c.err
+	}
+}
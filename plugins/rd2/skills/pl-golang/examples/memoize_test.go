@@ -0,0 +1,123 @@
+package examples
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoizeCachesSuccess(t *testing.T) {
+	var calls atomic.Int32
+	square := Memoize(func(n int) (int, error) {
+		calls.Add(1)
+		return n * n, nil
+	})
+
+	for i := 0; i < 3; i++ {
+		v, err := square(4)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v != 16 {
+			t.Errorf("expected 16, got %d", v)
+		}
+	}
+	if _, err := square(5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected 2 calls (one per key), got %d", n)
+	}
+}
+
+func TestMemoizeRetriesErrors(t *testing.T) {
+	errTransient := errors.New("transient")
+	var calls atomic.Int32
+	fetch := Memoize(func(key string) (string, error) {
+		if calls.Add(1) == 1 {
+			return "", errTransient
+		}
+		return "value:" + key, nil
+	})
+
+	if _, err := fetch("a"); !errors.Is(err, errTransient) {
+		t.Fatalf("expected transient error, got %v", err)
+	}
+	v, err := fetch("a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v != "value:a" {
+		t.Errorf("expected value:a, got %q", v)
+	}
+	if _, err := fetch("a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected 2 calls, got %d", n)
+	}
+}
+
+func TestMemoizeSingleFlight(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	slow := Memoize(func(n int) (int, error) {
+		calls.Add(1)
+		<-release
+		return n + 1, nil
+	})
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make(chan int, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := slow(1)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results <- v
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected fn to run once, ran %d times", n)
+	}
+	for v := range results {
+		if v != 2 {
+			t.Errorf("expected 2, got %d", v)
+		}
+	}
+}
+
+func TestMemoizePanicIsNotCached(t *testing.T) {
+	var calls atomic.Int32
+	fn := Memoize(func(n int) (int, error) {
+		if calls.Add(1) == 1 {
+			panic("boom")
+		}
+		return n, nil
+	})
+
+	func() {
+		defer func() { _ = recover() }()
+		_, _ = fn(1)
+	}()
+
+	v, err := fn(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v != 1 {
+		t.Errorf("expected 1, got %d", v)
+	}
+}
@@ -0,0 +1,149 @@
+package examples
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MetricKind says how samples of a metric combine within a flush window.
+type MetricKind int
+
+//go:generate stringer -type=MetricKind -trimprefix=Metric
+
+const (
+	MetricCounter MetricKind = iota // samples are summed
+	MetricGauge                     // the last sample wins
+)
+
+// Metric is one coalesced sample handed to a MetricsSink.
+type Metric struct {
+	Name  string
+	Kind  MetricKind
+	Value float64
+}
+
+// MetricsSink receives each flushed batch, in first-recorded order. It is
+// called from a single goroutine, never concurrently.
+type MetricsSink func(batch []Metric)
+
+type metricKey struct {
+	name string
+	kind MetricKind
+}
+
+// MetricsBatcher buffers metric samples and flushes them to a sink every
+// interval, or sooner once maxSize distinct metrics are pending. Samples for
+// the same metric are coalesced within a window, so a hot counter costs one
+// entry per flush rather than one per increment.
+//
+// When ctx is done the batcher makes a final flush and stops; samples
+// recorded after that are dropped. Done is closed once the final flush
+// returns.
+type MetricsBatcher struct {
+	sink    MetricsSink
+	maxSize int
+	kick    chan struct{}
+	done    chan struct{}
+
+	mu      sync.Mutex
+	pending []Metric
+	index   map[metricKey]int
+	stopped bool
+}
+
+// NewMetricsBatcher starts a batcher flushing to sink every interval. A
+// maxSize <= 0 disables size-triggered flushes. It panics if interval is not
+// positive.
+func NewMetricsBatcher(ctx context.Context, sink MetricsSink, interval time.Duration, maxSize int) *MetricsBatcher {
+	return newMetricsBatcherClock(ctx, RealClock, sink, interval, maxSize)
+}
+
+func newMetricsBatcherClock(ctx context.Context, clock Clock, sink MetricsSink, interval time.Duration, maxSize int) *MetricsBatcher {
+	if interval <= 0 {
+		panic("examples: metrics batcher interval must be positive")
+	}
+	b := &MetricsBatcher{
+		sink:    sink,
+		maxSize: maxSize,
+		kick:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		index:   make(map[metricKey]int),
+	}
+	ticker := clock.NewTicker(interval)
+	go b.run(ctx, ticker)
+	return b
+}
+
+// Count adds delta to the counter name.
+func (b *MetricsBatcher) Count(name string, delta float64) {
+	b.record(Metric{Name: name, Kind: MetricCounter, Value: delta})
+}
+
+// Gauge sets the gauge name to v.
+func (b *MetricsBatcher) Gauge(name string, v float64) {
+	b.record(Metric{Name: name, Kind: MetricGauge, Value: v})
+}
+
+// Done is closed after the final flush that follows ctx being done.
+func (b *MetricsBatcher) Done() <-chan struct{} {
+	return b.done
+}
+
+func (b *MetricsBatcher) record(m Metric) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.stopped {
+		return
+	}
+
+	key := metricKey{name: m.Name, kind: m.Kind}
+	if i, ok := b.index[key]; ok {
+		if m.Kind == MetricCounter {
+			b.pending[i].Value += m.Value
+		} else {
+			b.pending[i].Value = m.Value
+		}
+		return
+	}
+	b.index[key] = len(b.pending)
+	b.pending = append(b.pending, m)
+
+	if b.maxSize > 0 && len(b.pending) >= b.maxSize {
+		select {
+		case b.kick <- struct{}{}:
+		default: // a flush is already requested
+		}
+	}
+}
+
+func (b *MetricsBatcher) run(ctx context.Context, ticker Ticker) {
+	defer close(b.done)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			b.mu.Lock()
+			b.stopped = true
+			b.mu.Unlock()
+			b.flush()
+			return
+		case <-ticker.C():
+			b.flush()
+		case <-b.kick:
+			b.flush()
+		}
+	}
+}
+
+func (b *MetricsBatcher) flush() {
+	b.mu.Lock()
+	batch := b.pending
+	b.pending = nil
+	clear(b.index)
+	b.mu.Unlock()
+
+	if len(batch) > 0 {
+		b.sink(batch)
+	}
+}
@@ -0,0 +1,133 @@
+package examples
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// chanSink returns a MetricsSink that forwards each batch to the channel.
+func chanSink() (MetricsSink, <-chan []Metric) {
+	ch := make(chan []Metric, 10)
+	return func(batch []Metric) { ch <- batch }, ch
+}
+
+func recvBatch(t *testing.T, ch <-chan []Metric) []Metric {
+	t.Helper()
+	select {
+	case batch := <-ch:
+		return batch
+	case <-time.After(time.Second):
+		t.Fatal("expected a flush")
+		return nil
+	}
+}
+
+func TestMetricsBatcherIntervalFlush(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink, batches := chanSink()
+	b := newMetricsBatcherClock(ctx, clock, sink, 10*time.Second, 0)
+
+	b.Count("requests", 1)
+	select {
+	case batch := <-batches:
+		t.Fatalf("unexpected flush before the interval: %v", batch)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(10 * time.Second)
+	want := []Metric{{Name: "requests", Kind: MetricCounter, Value: 1}}
+	if got := recvBatch(t, batches); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMetricsBatcherThresholdFlush(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink, batches := chanSink()
+	b := newMetricsBatcherClock(ctx, clock, sink, time.Hour, 2)
+
+	b.Count("requests", 1)
+	b.Gauge("queue_depth", 7)
+
+	want := []Metric{
+		{Name: "requests", Kind: MetricCounter, Value: 1},
+		{Name: "queue_depth", Kind: MetricGauge, Value: 7},
+	}
+	if got := recvBatch(t, batches); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMetricsBatcherCoalesces(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink, batches := chanSink()
+	b := newMetricsBatcherClock(ctx, clock, sink, 10*time.Second, 3)
+
+	b.Count("requests", 1)
+	b.Gauge("queue_depth", 7)
+	b.Count("requests", 2)
+	b.Gauge("queue_depth", 3)
+	b.Count("requests", 4)
+
+	clock.Advance(10 * time.Second)
+	want := []Metric{
+		{Name: "requests", Kind: MetricCounter, Value: 7},
+		{Name: "queue_depth", Kind: MetricGauge, Value: 3},
+	}
+	if got := recvBatch(t, batches); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMetricsBatcherFinalFlushOnCancel(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	sink, batches := chanSink()
+	b := newMetricsBatcherClock(ctx, clock, sink, time.Hour, 0)
+
+	b.Count("shutdowns", 1)
+	cancel()
+
+	select {
+	case <-b.Done():
+	case <-time.After(time.Second):
+		t.Fatal("batcher did not stop after cancellation")
+	}
+	want := []Metric{{Name: "shutdowns", Kind: MetricCounter, Value: 1}}
+	if got := recvBatch(t, batches); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if n := clock.Pending(); n != 0 {
+		t.Errorf("expected ticker to be stopped, %d still pending", n)
+	}
+
+	b.Count("late", 1)
+	select {
+	case batch := <-batches:
+		t.Errorf("unexpected flush after shutdown: %v", batch)
+	default:
+	}
+}
+
+func TestMetricKindString(t *testing.T) {
+	if MetricCounter.String() != "Counter" || MetricGauge.String() != "Gauge" {
+		t.Errorf("unexpected names: %s, %s", MetricCounter, MetricGauge)
+	}
+}
+
+func TestNewMetricsBatcherInvalidInterval(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a zero interval")
+		}
+	}()
+	newMetricsBatcherClock(context.Background(), NewFakeClock(time.Unix(0, 0)), nil, 0, 10)
+}
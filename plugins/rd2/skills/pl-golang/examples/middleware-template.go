@@ -0,0 +1,67 @@
+package examples
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Middleware wraps an http.Handler with cross-cutting behavior.
+type Middleware func(http.Handler) http.Handler
+
+// Chain composes mws so the first one is the outermost: a request passes
+// through mws[0], then mws[1], and so on before reaching the handler.
+//
+// Example:
+//
+//	handler := Chain(Logging(logger), Recovery())(mux)
+func Chain(mws ...Middleware) Middleware {
+	return func(next http.Handler) http.Handler {
+		for i := len(mws) - 1; i >= 0; i-- {
+			next = mws[i](next)
+		}
+		return next
+	}
+}
+
+// statusRecorder captures the status code written by the wrapped handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to
+// flush a streaming response.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Logging logs one line per request with its method, path, status, and
+// duration.
+func Logging(logger *slog.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			logger.LogAttrs(r.Context(), slog.LevelInfo, "request",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", rec.status),
+				slog.Duration("duration", time.Since(start)),
+			)
+		})
+	}
+}
+
+// Recovery converts a panic in the handler into a 500 response written by
+// WriteError, keeping the server alive. Use RecoverMiddleware to also log the
+// panic.
+func Recovery() Middleware {
+	return RecoverMiddleware(nil)
+}
@@ -0,0 +1,92 @@
+package examples
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestChainOrder(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, name+" in")
+				next.ServeHTTP(w, r)
+				calls = append(calls, name+" out")
+			})
+		}
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "handler")
+	})
+
+	Chain(trace("a"), trace("b"), trace("c"))(handler).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	want := []string{"a in", "b in", "c in", "handler", "c out", "b out", "a out"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected %v, got %v", want, calls)
+	}
+}
+
+func TestChainEmpty(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Chain()(http.NotFoundHandler()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected handler to run unchanged, got %d", rec.Code)
+	}
+}
+
+func TestRecoveryReturns500(t *testing.T) {
+	panicky := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("secret: db password is hunter2")
+	})
+
+	srv := httptest.NewServer(Recovery()(panicky))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", resp.StatusCode)
+	}
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(resp.Body)
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("panic details leaked to client: %s", buf.String())
+	}
+
+	// The server must still serve requests after the panic.
+	resp2, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("server did not survive panic: %v", err)
+	}
+	resp2.Body.Close()
+}
+
+func TestLoggingRecordsStatus(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	handler := Chain(Logging(logger), Recovery())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/orders", nil))
+
+	out := buf.String()
+	for _, want := range []string{"method=POST", "path=/orders", "status=500"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected log to contain %q, got: %s", want, out)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package examples
+
+import "container/list"
+
+// OrderedMap is a map that remembers insertion order, for output or
+// processing that must be deterministic. Re-setting a key updates its value
+// in place; deleting and re-adding it moves it to the end. Get, Set and
+// Delete are O(1). It is not safe for concurrent use.
+type OrderedMap[K comparable, V any] struct {
+	index map[K]*list.Element
+	order *list.List // of *orderedEntry[K, V]
+}
+
+type orderedEntry[K comparable, V any] struct {
+	key K
+	val V
+}
+
+// NewOrderedMap returns an empty OrderedMap.
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{index: make(map[K]*list.Element), order: list.New()}
+}
+
+// Set stores v under k, appending k if it is new.
+func (m *OrderedMap[K, V]) Set(k K, v V) {
+	if e, ok := m.index[k]; ok {
+		e.Value.(*orderedEntry[K, V]).val = v
+		return
+	}
+	m.index[k] = m.order.PushBack(&orderedEntry[K, V]{key: k, val: v})
+}
+
+// Get returns the value stored under k.
+func (m *OrderedMap[K, V]) Get(k K) (V, bool) {
+	if e, ok := m.index[k]; ok {
+		return e.Value.(*orderedEntry[K, V]).val, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete removes k, reporting whether it was present.
+func (m *OrderedMap[K, V]) Delete(k K) bool {
+	e, ok := m.index[k]
+	if !ok {
+		return false
+	}
+	m.order.Remove(e)
+	delete(m.index, k)
+	return true
+}
+
+// Len returns the number of entries.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.index)
+}
+
+// Range calls fn for each entry in insertion order until fn returns false.
+// fn must not modify the map.
+func (m *OrderedMap[K, V]) Range(fn func(k K, v V) bool) {
+	for e := m.order.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*orderedEntry[K, V])
+		if !fn(entry.key, entry.val) {
+			return
+		}
+	}
+}
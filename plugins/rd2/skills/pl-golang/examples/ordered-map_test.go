@@ -0,0 +1,83 @@
+package examples
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// entries renders m's entries in iteration order.
+func entries[V any](m *OrderedMap[string, V]) []string {
+	var out []string
+	m.Range(func(k string, v V) bool {
+		out = append(out, fmt.Sprintf("%s=%v", k, v))
+		return true
+	})
+	return out
+}
+
+func TestOrderedMapInsertionOrder(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	for _, k := range []string{"zeta", "alpha", "mid"} {
+		m.Set(k, len(k))
+	}
+
+	if got, want := entries(m), []string{"zeta=4", "alpha=5", "mid=3"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if v, ok := m.Get("alpha"); !ok || v != 5 {
+		t.Errorf("expected (5, true), got (%d, %v)", v, ok)
+	}
+	if _, ok := m.Get("missing"); ok {
+		t.Error("expected missing key to be absent")
+	}
+}
+
+func TestOrderedMapUpdateKeepsPosition(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("a", 10)
+
+	if got, want := entries(m), []string{"a=10", "b=2"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if m.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", m.Len())
+	}
+}
+
+func TestOrderedMapDeleteReinsertMovesToEnd(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+
+	if !m.Delete("a") {
+		t.Fatal("expected a to be deleted")
+	}
+	if m.Delete("a") {
+		t.Error("expected second delete to report false")
+	}
+	m.Set("a", 4)
+
+	if got, want := entries(m), []string{"b=2", "c=3", "a=4"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestOrderedMapRangeStops(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+
+	var seen []string
+	m.Range(func(k string, _ int) bool {
+		seen = append(seen, k)
+		return k != "b"
+	})
+	if want := []string{"a", "b"}; !slices.Equal(seen, want) {
+		t.Errorf("expected Range to stop after b, saw %v", seen)
+	}
+}
@@ -0,0 +1,104 @@
+package examples
+
+import (
+	"context"
+	"sync"
+)
+
+// Generate emits vals in order and closes the channel, stopping early if ctx
+// is canceled.
+func Generate[T any](ctx context.Context, vals ...T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for _, v := range vals {
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// MapStage applies fn to every value from in on a single goroutine, so the
+// output order matches the input order.
+func MapStage[T, R any](ctx context.Context, in <-chan T, fn func(T) R) <-chan R {
+	out := make(chan R)
+	go func() {
+		defer close(out)
+		for v := range in {
+			select {
+			case out <- fn(v):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Collect drains in into a slice until it is closed.
+func Collect[T any](in <-chan T) []T {
+	var out []T
+	for v := range in {
+		out = append(out, v)
+	}
+	return out
+}
+
+// StreamMap processes values from in with the given number of workers and
+// streams each result or error as soon as it is ready, so output order is
+// not preserved. Both output channels are closed once in is closed (or ctx
+// is done) and every worker has returned. Callers must drain both channels
+// or cancel ctx.
+func StreamMap[T, R any](ctx context.Context, in <-chan T, workers int, fn func(context.Context, T) (R, error)) (<-chan R, <-chan error) {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make(chan R)
+	errs := make(chan error)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				var v T
+				select {
+				case <-ctx.Done():
+					return
+				case item, ok := <-in:
+					if !ok {
+						return
+					}
+					v = item
+				}
+
+				r, err := fn(ctx, v)
+				if err != nil {
+					select {
+					case errs <- err:
+					case <-ctx.Done():
+						return
+					}
+					continue
+				}
+				select {
+				case results <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+		close(errs)
+	}()
+	return results, errs
+}
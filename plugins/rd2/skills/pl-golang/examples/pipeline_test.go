@@ -0,0 +1,130 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPipelinePreservesOrder(t *testing.T) {
+	ctx := context.Background()
+
+	in := make([]int, 100)
+	for i := range in {
+		in[i] = i
+	}
+
+	squared := MapStage(ctx, Generate(ctx, in...), func(n int) int { return n * n })
+	shifted := MapStage(ctx, squared, func(n int) int { return n + 1 })
+	got := Collect(shifted)
+
+	if len(got) != len(in) {
+		t.Fatalf("expected %d values, got %d", len(in), len(got))
+	}
+	AssertMonotonic(t, got)
+}
+
+func TestPipelineCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	out := MapStage(ctx, Generate(ctx, 1, 2, 3, 4, 5), func(n int) int { return n })
+	if v := <-out; v != 1 {
+		t.Fatalf("expected first value 1, got %d", v)
+	}
+	cancel()
+
+	// The stage must close its output once canceled; draining must terminate.
+	for range out {
+	}
+}
+
+// drainStream collects everything from a StreamMap result until both
+// channels are closed.
+func drainStream[R any](results <-chan R, errs <-chan error) ([]R, []error) {
+	var out []R
+	var errList []error
+	for results != nil || errs != nil {
+		select {
+		case r, ok := <-results:
+			if !ok {
+				results = nil
+				continue
+			}
+			out = append(out, r)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			errList = append(errList, err)
+		}
+	}
+	return out, errList
+}
+
+func TestStreamMapConcurrent(t *testing.T) {
+	ctx := context.Background()
+	var peaks peakTracker
+
+	results, errs := StreamMap(ctx, Generate(ctx, 1, 2, 3, 4, 5, 6, 7, 8), 4, func(_ context.Context, n int) (int, error) {
+		defer peaks.enter()()
+		time.Sleep(10 * time.Millisecond)
+		return n * 10, nil
+	})
+
+	got, errList := drainStream(results, errs)
+	if len(errList) != 0 {
+		t.Fatalf("unexpected errors: %v", errList)
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, []int{10, 20, 30, 40, 50, 60, 70, 80}) {
+		t.Errorf("unexpected results: %v", got)
+	}
+	if p := peaks.Peak(); p < 2 {
+		t.Errorf("expected concurrent processing, peak %d", p)
+	}
+}
+
+func TestStreamMapSurfacesErrors(t *testing.T) {
+	ctx := context.Background()
+	errOdd := errors.New("odd")
+
+	results, errs := StreamMap(ctx, Generate(ctx, 1, 2, 3, 4), 2, func(_ context.Context, n int) (string, error) {
+		if n%2 == 1 {
+			return "", fmt.Errorf("item %d: %w", n, errOdd)
+		}
+		return fmt.Sprint(n), nil
+	})
+
+	got, errList := drainStream(results, errs)
+	if len(got) != 2 || len(errList) != 2 {
+		t.Fatalf("expected 2 results and 2 errors, got %v and %v", got, errList)
+	}
+	for _, err := range errList {
+		if !errors.Is(err, errOdd) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestStreamMapClosesOnCancel(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	in := make(chan int) // never closed
+	results, errs := StreamMap(ctx, in, 3, func(_ context.Context, n int) (int, error) {
+		return n, nil
+	})
+
+	in <- 1
+	AssertRecv(t, results, 1, time.Second)
+	cancel()
+
+	AssertChanClosed(t, results, time.Second)
+	AssertChanClosed(t, errs, time.Second)
+	assertNoGoroutineLeak(t, baseline)
+}
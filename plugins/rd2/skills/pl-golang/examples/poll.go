@@ -0,0 +1,36 @@
+package examples
+
+import (
+	"context"
+	"time"
+)
+
+// PollUntil calls check until it reports true, returns an error, or ctx is
+// done. The wait between calls starts at initial and doubles up to
+// maxInterval, which suits waiting on eventually consistent state without
+// hammering it. A maxInterval below initial is raised to initial. It panics
+// if initial is not positive, since polling would then spin.
+func PollUntil(ctx context.Context, initial, maxInterval time.Duration, check func(context.Context) (bool, error)) error {
+	return pollUntilClock(ctx, RealClock, initial, maxInterval, check)
+}
+
+func pollUntilClock(ctx context.Context, clock Clock, initial, maxInterval time.Duration, check func(context.Context) (bool, error)) error {
+	if initial <= 0 {
+		panic("examples: poll interval must be positive")
+	}
+	maxInterval = max(maxInterval, initial)
+	interval := initial
+	for {
+		ok, err := check(ctx)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		if err := sleepClock(ctx, clock, interval); err != nil {
+			return err
+		}
+		interval = min(interval*2, maxInterval)
+	}
+}
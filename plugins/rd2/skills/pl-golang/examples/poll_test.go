@@ -0,0 +1,113 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPollUntilBackoffSequence(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewFakeClock(start)
+
+	var offsets []time.Duration
+	check := func(context.Context) (bool, error) {
+		offsets = append(offsets, clock.Now().Sub(start))
+		return len(offsets) == 5, nil
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- pollUntilClock(context.Background(), clock, time.Second, 4*time.Second, check) }()
+
+	for _, d := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		clock.BlockUntil(1)
+		clock.Advance(d)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []time.Duration{0, time.Second, 3 * time.Second, 7 * time.Second, 11 * time.Second}
+	if !slices.Equal(offsets, want) {
+		t.Errorf("expected checks at %v, got %v", want, offsets)
+	}
+}
+
+func TestPollUntilEarlySuccess(t *testing.T) {
+	calls := 0
+	err := PollUntil(context.Background(), time.Hour, time.Hour, func(context.Context) (bool, error) {
+		calls++
+		return true, nil
+	})
+
+	if err != nil || calls != 1 {
+		t.Errorf("expected immediate success after 1 call, got %v after %d", err, calls)
+	}
+}
+
+func TestPollUntilErrorPropagates(t *testing.T) {
+	errCheck := errors.New("status endpoint down")
+	calls := 0
+	err := PollUntil(context.Background(), time.Millisecond, time.Millisecond, func(context.Context) (bool, error) {
+		calls++
+		if calls == 3 {
+			return false, errCheck
+		}
+		return false, nil
+	})
+
+	if !errors.Is(err, errCheck) {
+		t.Errorf("expected check error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected polling to stop at the error, got %d calls", calls)
+	}
+}
+
+func TestPollUntilContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := PollUntil(ctx, time.Millisecond, 5*time.Millisecond, func(context.Context) (bool, error) {
+		return false, nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestPollUntilMaxBelowInitial(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewFakeClock(start)
+
+	var offsets []time.Duration
+	check := func(context.Context) (bool, error) {
+		offsets = append(offsets, clock.Now().Sub(start))
+		return len(offsets) == 3, nil
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- pollUntilClock(context.Background(), clock, time.Second, 0, check) }()
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []time.Duration{0, time.Second, 2 * time.Second}; !slices.Equal(offsets, want) {
+		t.Errorf("expected a steady 1s interval, got checks at %v", offsets)
+	}
+}
+
+func TestPollUntilNonPositiveInitialPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a zero initial interval")
+		}
+	}()
+	PollUntil(context.Background(), 0, time.Second, func(context.Context) (bool, error) { return false, nil })
+}
@@ -0,0 +1,148 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrPoolClosed is returned when submitting to a closed Pool.
+var ErrPoolClosed = errors.New("pool closed")
+
+// Pool runs submitted tasks on long-lived worker goroutines whose number can
+// be changed at runtime with Resize.
+type Pool struct {
+	tasks chan func()
+
+	mu         sync.RWMutex
+	stops      []chan struct{} // one per live worker, newest last
+	closed     bool
+	quit       chan struct{}  // closed by Close to release blocked submitters
+	submitters sync.WaitGroup // Submits that may still send on tasks
+
+	live atomic.Int64
+	wg   sync.WaitGroup
+}
+
+// NewPool starts a pool with the given number of workers and a task queue of
+// queueSize.
+func NewPool(workers, queueSize int) *Pool {
+	p := &Pool{tasks: make(chan func(), queueSize), quit: make(chan struct{})}
+	p.Resize(workers)
+	return p
+}
+
+// Submit queues task, blocking while the queue is full until ctx is done or
+// the pool is closed. It does not hold the pool's lock while blocked, so
+// Resize and Close are never held up by a full queue.
+func (p *Pool) Submit(ctx context.Context, task func()) error {
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return ErrPoolClosed
+	}
+	p.submitters.Add(1)
+	p.mu.RUnlock()
+	defer p.submitters.Done()
+
+	select {
+	case p.tasks <- task:
+		return nil
+	case <-p.quit:
+		return ErrPoolClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Resize grows or shrinks the pool to n workers. Removed workers finish the
+// task they are running before exiting; queued tasks are left for the
+// remaining workers.
+func (p *Pool) Resize(n int) {
+	if n < 0 {
+		n = 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+
+	for len(p.stops) < n {
+		stop := make(chan struct{})
+		p.stops = append(p.stops, stop)
+		p.wg.Add(1)
+		go p.worker(stop)
+	}
+	for len(p.stops) > n {
+		last := len(p.stops) - 1
+		close(p.stops[last])
+		p.stops = p.stops[:last]
+	}
+}
+
+// Size returns the target number of workers.
+func (p *Pool) Size() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.stops)
+}
+
+// Running returns the number of worker goroutines still alive, which lags
+// Size after a shrink until busy workers finish.
+func (p *Pool) Running() int {
+	return int(p.live.Load())
+}
+
+// Close stops accepting tasks, fails blocked Submits with ErrPoolClosed,
+// lets the workers drain the queue, and waits for them to exit. If the pool
+// was resized to zero, Close starts one worker to drain it, so queued tasks
+// always run.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.quit)
+	if len(p.stops) == 0 {
+		p.wg.Add(1)
+		go p.worker(nil) // a nil stop never fires; it exits when tasks closes
+	}
+	p.mu.Unlock()
+
+	// No new submitters can start; wait for blocked ones to give up before
+	// closing tasks so none of them sends on a closed channel.
+	p.submitters.Wait()
+	close(p.tasks)
+	p.wg.Wait()
+}
+
+func (p *Pool) worker(stop <-chan struct{}) {
+	p.live.Add(1)
+	defer func() {
+		p.live.Add(-1)
+		p.wg.Done()
+	}()
+
+	for {
+		// Prefer stopping over picking up more work once resized away.
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		select {
+		case <-stop:
+			return
+		case task, ok := <-p.tasks:
+			if !ok {
+				return
+			}
+			task()
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// runLoad submits n tasks that each hold a slot for d and returns the peak
+// number of tasks observed running at once.
+func runLoad(t *testing.T, p *Pool, n int, d time.Duration) int64 {
+	t.Helper()
+	var peaks peakTracker
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		err := p.Submit(context.Background(), func() {
+			defer wg.Done()
+			exit := peaks.enter()
+			time.Sleep(d)
+			exit()
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	wg.Wait()
+	return peaks.Peak()
+}
+
+func waitForRunning(t *testing.T, p *Pool, want int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for p.Running() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d running workers, got %d", want, p.Running())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestPoolResizeUp(t *testing.T) {
+	p := NewPool(1, 16)
+	defer p.Close()
+
+	if peak := runLoad(t, p, 4, 10*time.Millisecond); peak != 1 {
+		t.Fatalf("expected peak concurrency 1, got %d", peak)
+	}
+
+	p.Resize(4)
+	waitForRunning(t, p, 4)
+
+	if peak := runLoad(t, p, 8, 20*time.Millisecond); peak < 2 {
+		t.Errorf("expected more throughput after resize, peak concurrency %d", peak)
+	}
+}
+
+func TestPoolResizeDownFinishesInFlight(t *testing.T) {
+	p := NewPool(4, 16)
+	defer p.Close()
+	waitForRunning(t, p, 4)
+
+	release := make(chan struct{})
+	var finished atomic.Int64
+	var started sync.WaitGroup
+	started.Add(4)
+	for i := 0; i < 4; i++ {
+		_ = p.Submit(context.Background(), func() {
+			started.Done()
+			<-release
+			finished.Add(1)
+		})
+	}
+	started.Wait()
+
+	p.Resize(1)
+	if p.Size() != 1 {
+		t.Errorf("expected Size 1, got %d", p.Size())
+	}
+	// Busy workers keep running until their task completes.
+	if p.Running() != 4 {
+		t.Errorf("expected 4 running workers while busy, got %d", p.Running())
+	}
+
+	close(release)
+	waitForRunning(t, p, 1)
+	if finished.Load() != 4 {
+		t.Errorf("expected all in-flight tasks to finish, got %d", finished.Load())
+	}
+}
+
+func TestPoolCloseDrainsQueue(t *testing.T) {
+	p := NewPool(2, 8)
+
+	var done atomic.Int64
+	for i := 0; i < 8; i++ {
+		_ = p.Submit(context.Background(), func() { done.Add(1) })
+	}
+	p.Close()
+
+	if done.Load() != 8 {
+		t.Errorf("expected 8 tasks run, got %d", done.Load())
+	}
+	if err := p.Submit(context.Background(), func() {}); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("expected ErrPoolClosed, got %v", err)
+	}
+}
+
+func TestPoolCloseDrainsQueueWithNoWorkers(t *testing.T) {
+	p := NewPool(1, 4)
+	p.Resize(0)
+	waitForRunning(t, p, 0)
+
+	var done atomic.Int64
+	for i := 0; i < 4; i++ {
+		if err := p.Submit(context.Background(), func() { done.Add(1) }); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	p.Close()
+
+	if done.Load() != 4 {
+		t.Errorf("expected Close to run the 4 queued tasks, got %d", done.Load())
+	}
+	if n := p.Running(); n != 0 {
+		t.Errorf("expected no workers left after Close, got %d", n)
+	}
+}
+
+func TestPoolResizeWhileSubmitBlocked(t *testing.T) {
+	p := NewPool(0, 1)
+	defer p.Close()
+	if err := p.Submit(context.Background(), func() {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The queue is full and nothing drains it, so this Submit blocks.
+	ran := make(chan struct{})
+	submitted := make(chan error, 1)
+	go func() {
+		submitted <- p.Submit(context.Background(), func() { close(ran) })
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	resized := make(chan struct{})
+	go func() {
+		p.Resize(1)
+		close(resized)
+	}()
+	select {
+	case <-resized:
+	case <-time.After(time.Second):
+		t.Fatal("expected Resize not to wait for the blocked Submit")
+	}
+
+	if err := <-submitted; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("expected the new worker to drain the queue")
+	}
+}
+
+func TestPoolCloseReleasesBlockedSubmit(t *testing.T) {
+	p := NewPool(0, 1)
+	if err := p.Submit(context.Background(), func() {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	submitted := make(chan error, 1)
+	go func() {
+		submitted <- p.Submit(context.Background(), func() {})
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	closed := make(chan struct{})
+	go func() {
+		p.Close()
+		close(closed)
+	}()
+	select {
+	case err := <-submitted:
+		if !errors.Is(err, ErrPoolClosed) {
+			t.Errorf("expected ErrPoolClosed, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Close to release the blocked Submit")
+	}
+	<-closed
+}
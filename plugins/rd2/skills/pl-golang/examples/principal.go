@@ -0,0 +1,51 @@
+package examples
+
+import (
+	"context"
+	"slices"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	UserID string
+	Roles  []string
+	Scopes []string
+}
+
+// HasRole reports whether p has role.
+func (p Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+// HasScope reports whether p was granted scope.
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+var principalKey = NewContextKey[Principal]("principal")
+
+// WithPrincipal returns a child context carrying p. Authentication middleware
+// sets it once; handlers read it with PrincipalFrom.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return principalKey.WithValue(ctx, p)
+}
+
+// PrincipalFrom returns the principal stored by WithPrincipal.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	return principalKey.Value(ctx)
+}
+
+// RequireRole returns nil if ctx's principal has role. Otherwise it returns
+// a CodedError: CodeUnauthenticated when there is no principal and
+// CodePermissionDenied when the role is missing, so WriteError maps it to
+// 401 or 403.
+func RequireRole(ctx context.Context, role string) error {
+	p, ok := PrincipalFrom(ctx)
+	if !ok {
+		return NewCoded(CodeUnauthenticated, "authentication required")
+	}
+	if !p.HasRole(role) {
+		return NewCoded(CodePermissionDenied, "missing role "+role)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package examples
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPrincipalFrom(t *testing.T) {
+	want := Principal{UserID: "u-1", Roles: []string{"admin"}, Scopes: []string{"orders:read"}}
+	ctx := WithPrincipal(context.Background(), want)
+
+	got, ok := PrincipalFrom(ctx)
+	if !ok {
+		t.Fatal("expected principal in context")
+	}
+	if got.UserID != want.UserID || !got.HasRole("admin") || !got.HasScope("orders:read") {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+	if got.HasScope("orders:write") {
+		t.Error("expected ungranted scope to be absent")
+	}
+
+	if _, ok := PrincipalFrom(context.Background()); ok {
+		t.Error("expected no principal on a bare context")
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	admin := WithPrincipal(context.Background(), Principal{UserID: "u-1", Roles: []string{"admin", "user"}})
+	user := WithPrincipal(context.Background(), Principal{UserID: "u-2", Roles: []string{"user"}})
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		code ErrorCode // CodeUnknown means allowed
+	}{
+		{"allowed", admin, CodeUnknown},
+		{"denied", user, CodePermissionDenied},
+		{"missing principal", context.Background(), CodeUnauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RequireRole(tt.ctx, "admin")
+			if tt.code == CodeUnknown {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if code, ok := CodeOf(err); !ok || code != tt.code {
+				t.Errorf("expected code %v, got %v (err %v)", tt.code, code, err)
+			}
+		})
+	}
+}
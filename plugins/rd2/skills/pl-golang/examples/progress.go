@@ -0,0 +1,73 @@
+package examples
+
+import (
+	"context"
+	"sync"
+)
+
+// Progress is a snapshot of a long-running job.
+type Progress struct {
+	Done  int
+	Total int
+}
+
+// Percent returns completion in the range [0, 100].
+func (p Progress) Percent() float64 {
+	if p.Total <= 0 {
+		return 0
+	}
+	return float64(p.Done) / float64(p.Total) * 100
+}
+
+// ProgressReporter publishes Progress updates from a worker to a consumer
+// without ever blocking the worker. A slow consumer sees only the latest
+// update. The updates channel is closed when the context is done or Close
+// is called.
+type ProgressReporter struct {
+	mu     sync.Mutex
+	ch     chan Progress
+	closed bool
+	stop   func() bool
+}
+
+// NewProgressReporter returns a reporter bound to ctx.
+func NewProgressReporter(ctx context.Context) *ProgressReporter {
+	r := &ProgressReporter{ch: make(chan Progress, 1)}
+	r.stop = context.AfterFunc(ctx, r.close)
+	return r
+}
+
+// Updates returns the channel of progress snapshots.
+func (r *ProgressReporter) Updates() <-chan Progress {
+	return r.ch
+}
+
+// Report publishes a snapshot, replacing any snapshot the consumer has not
+// read yet. It is a no-op after the reporter is closed.
+func (r *ProgressReporter) Report(done, total int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	select {
+	case <-r.ch:
+	default:
+	}
+	r.ch <- Progress{Done: done, Total: total}
+}
+
+// Close closes the updates channel once the worker has finished.
+func (r *ProgressReporter) Close() {
+	r.stop()
+	r.close()
+}
+
+func (r *ProgressReporter) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.closed {
+		r.closed = true
+		close(r.ch)
+	}
+}
@@ -0,0 +1,57 @@
+package examples
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestProgressReporterLatestValue(t *testing.T) {
+	r := NewProgressReporter(context.Background())
+	defer r.Close()
+
+	for i := 1; i <= 5; i++ {
+		r.Report(i, 5) // never blocks, even with no reader
+	}
+
+	AssertRecv(t, r.Updates(), Progress{Done: 5, Total: 5}, time.Second)
+
+	select {
+	case p := <-r.Updates():
+		t.Errorf("expected only the latest update, also got %+v", p)
+	default:
+	}
+
+	r.Report(6, 10)
+	AssertRecv(t, r.Updates(), Progress{Done: 6, Total: 10}, time.Second)
+}
+
+func TestProgressReporterCancelCloses(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := NewProgressReporter(ctx)
+
+	cancel()
+	AssertChanClosed(t, r.Updates(), time.Second)
+
+	// Reporting after cancellation must not panic on the closed channel.
+	r.Report(1, 1)
+	r.Close()
+}
+
+func TestProgressPercent(t *testing.T) {
+	tests := []struct {
+		p        Progress
+		expected float64
+	}{
+		{Progress{Done: 0, Total: 4}, 0},
+		{Progress{Done: 1, Total: 4}, 25},
+		{Progress{Done: 4, Total: 4}, 100},
+		{Progress{Done: 3, Total: 0}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.p.Percent(); got != tt.expected {
+			t.Errorf("%+v.Percent() = %v, want %v", tt.p, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Rows is the subset of *sql.Rows used by the query examples.
+type Rows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+	Close() error
+}
+
+// Querier mirrors (*sql.DB).QueryContext. A thin adapter returning *sql.Rows
+// as Rows lets a real database satisfy it.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (Rows, error)
+}
+
+// User is a row of the users table.
+type User struct {
+	ID   int64
+	Name string
+	Age  int
+}
+
+// QueryUsers returns users at least minAge years old. The context bounds the
+// whole query, including row iteration.
+func QueryUsers(ctx context.Context, db Querier, minAge int) (users []User, err error) {
+	rows, err := db.QueryContext(ctx, "SELECT id, name, age FROM users WHERE age >= ? ORDER BY id", minAge)
+	if err != nil {
+		return nil, fmt.Errorf("query users: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); cerr != nil {
+			err = errors.Join(err, fmt.Errorf("close rows: %w", cerr))
+		}
+	}()
+
+	for rows.Next() {
+		var u User
+		if err := rows.Scan(&u.ID, &u.Name, &u.Age); err != nil {
+			return nil, fmt.Errorf("scan user: %w", err)
+		}
+		users = append(users, u)
+	}
+	// Next returns false on both exhaustion and failure (including context
+	// cancellation mid-iteration); only Err tells them apart.
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate users: %w", err)
+	}
+	return users, nil
+}
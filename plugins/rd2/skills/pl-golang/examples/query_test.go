@@ -0,0 +1,133 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// fakeQuerier serves users from memory, honoring the minAge argument.
+type fakeQuerier struct {
+	users    []User
+	queryErr error
+	closeErr error
+}
+
+func (q *fakeQuerier) QueryContext(ctx context.Context, query string, args ...any) (Rows, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if q.queryErr != nil {
+		return nil, q.queryErr
+	}
+	minAge := args[0].(int)
+	var matched []User
+	for _, u := range q.users {
+		if u.Age >= minAge {
+			matched = append(matched, u)
+		}
+	}
+	return &fakeRows{ctx: ctx, users: matched, pos: -1, closeErr: q.closeErr}, nil
+}
+
+type fakeRows struct {
+	ctx      context.Context
+	users    []User
+	pos      int
+	err      error
+	closeErr error
+	closed   bool
+}
+
+func (r *fakeRows) Next() bool {
+	if err := r.ctx.Err(); err != nil {
+		r.err = err
+		return false
+	}
+	r.pos++
+	return r.pos < len(r.users)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	u := r.users[r.pos]
+	*dest[0].(*int64) = u.ID
+	*dest[1].(*string) = u.Name
+	*dest[2].(*int) = u.Age
+	return nil
+}
+
+func (r *fakeRows) Err() error { return r.err }
+
+func (r *fakeRows) Close() error {
+	r.closed = true
+	return r.closeErr
+}
+
+func TestQueryUsers(t *testing.T) {
+	db := &fakeQuerier{users: []User{
+		{ID: 1, Name: "ann", Age: 17},
+		{ID: 2, Name: "bob", Age: 30},
+		{ID: 3, Name: "cid", Age: 42},
+	}}
+
+	users, err := QueryUsers(context.Background(), db, 18)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users) != 2 || users[0].Name != "bob" || users[1].Name != "cid" {
+		t.Errorf("unexpected users: %+v", users)
+	}
+}
+
+func TestQueryUsersQueryError(t *testing.T) {
+	errDB := errors.New("connection refused")
+	db := &fakeQuerier{queryErr: errDB}
+
+	_, err := QueryUsers(context.Background(), db, 0)
+	if !errors.Is(err, errDB) {
+		t.Errorf("expected wrapped query error, got %v", err)
+	}
+}
+
+func TestQueryUsersCloseErrorJoined(t *testing.T) {
+	errClose := errors.New("close failed")
+	db := &fakeQuerier{users: []User{{ID: 1, Age: 20}}, closeErr: errClose}
+
+	_, err := QueryUsers(context.Background(), db, 0)
+	if !errors.Is(err, errClose) {
+		t.Errorf("expected close error to surface, got %v", err)
+	}
+}
+
+func TestQueryUsersContextCanceled(t *testing.T) {
+	users := make([]User, 10)
+	for i := range users {
+		users[i] = User{ID: int64(i), Name: fmt.Sprint("u", i), Age: 30}
+	}
+	db := &fakeQuerier{users: users}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := QueryUsers(ctx, db, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Canceled before query, got %v", err)
+	}
+
+	// Cancellation during iteration surfaces through rows.Err().
+	ctx, cancel = context.WithCancel(context.Background())
+	rows, _ := db.QueryContext(ctx, "", 0)
+	cancel()
+	db2 := querierFunc(func(context.Context, string, ...any) (Rows, error) { return rows, nil })
+	if _, err := QueryUsers(ctx, db2, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Canceled during iteration, got %v", err)
+	}
+	if !rows.(*fakeRows).closed {
+		t.Error("expected rows to be closed")
+	}
+}
+
+type querierFunc func(ctx context.Context, query string, args ...any) (Rows, error)
+
+func (f querierFunc) QueryContext(ctx context.Context, query string, args ...any) (Rows, error) {
+	return f(ctx, query, args...)
+}
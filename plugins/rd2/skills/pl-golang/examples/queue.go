@@ -0,0 +1,63 @@
+package examples
+
+import "context"
+
+// Queue is a bounded FIFO queue whose blocking operations respect context
+// cancellation.
+type Queue[T any] struct {
+	items chan T
+}
+
+// NewQueue returns a Queue holding at most capacity items. It panics if
+// capacity is not positive.
+func NewQueue[T any](capacity int) *Queue[T] {
+	if capacity <= 0 {
+		panic("examples: queue capacity must be positive")
+	}
+	return &Queue[T]{items: make(chan T, capacity)}
+}
+
+// Enqueue adds v, blocking while the queue is full until space frees up or
+// ctx is done.
+func (q *Queue[T]) Enqueue(ctx context.Context, v T) error {
+	select {
+	case q.items <- v:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Dequeue removes the oldest item, blocking while the queue is empty until an
+// item arrives or ctx is done.
+func (q *Queue[T]) Dequeue(ctx context.Context) (T, error) {
+	select {
+	case v := <-q.items:
+		return v, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// TryDequeue removes the oldest item without blocking. It reports false if
+// the queue is empty.
+func (q *Queue[T]) TryDequeue() (T, bool) {
+	select {
+	case v := <-q.items:
+		return v, true
+	default:
+		var zero T
+		return zero, false
+	}
+}
+
+// Len returns the number of queued items.
+func (q *Queue[T]) Len() int {
+	return len(q.items)
+}
+
+// Cap returns the queue capacity.
+func (q *Queue[T]) Cap() int {
+	return cap(q.items)
+}
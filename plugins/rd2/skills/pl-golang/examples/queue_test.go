@@ -0,0 +1,115 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQueueFIFO(t *testing.T) {
+	ctx := context.Background()
+	q := NewQueue[int](3)
+
+	for i := 1; i <= 3; i++ {
+		if err := q.Enqueue(ctx, i); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	for want := 1; want <= 3; want++ {
+		got, err := q.Dequeue(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("expected %d, got %d", want, got)
+		}
+	}
+
+	if _, ok := q.TryDequeue(); ok {
+		t.Error("expected empty queue")
+	}
+}
+
+func TestQueueBlocksUntilSpace(t *testing.T) {
+	ctx := context.Background()
+	q := NewQueue[string](1)
+	_ = q.Enqueue(ctx, "first")
+
+	done := make(chan error, 1)
+	go func() { done <- q.Enqueue(ctx, "second") }()
+
+	select {
+	case <-done:
+		t.Fatal("Enqueue should block while the queue is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if v, ok := q.TryDequeue(); !ok || v != "first" {
+		t.Fatalf("expected first, got %q (ok=%v)", v, ok)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Enqueue did not unblock after space freed")
+	}
+	if q.Len() != 1 {
+		t.Errorf("expected Len 1, got %d", q.Len())
+	}
+}
+
+func TestQueueBlocksUntilItem(t *testing.T) {
+	ctx := context.Background()
+	q := NewQueue[int](2)
+
+	got := make(chan int, 1)
+	go func() {
+		v, err := q.Dequeue(ctx)
+		if err == nil {
+			got <- v
+		}
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	_ = q.Enqueue(ctx, 42)
+
+	select {
+	case v := <-got:
+		if v != 42 {
+			t.Errorf("expected 42, got %d", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Dequeue did not unblock after item arrived")
+	}
+}
+
+func TestQueueContextCancel(t *testing.T) {
+	q := NewQueue[int](1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := q.Dequeue(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Dequeue: expected DeadlineExceeded, got %v", err)
+	}
+
+	_ = q.Enqueue(context.Background(), 1)
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	cancel2()
+	if err := q.Enqueue(ctx2, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Enqueue: expected Canceled, got %v", err)
+	}
+}
+
+func TestNewQueueInvalidCapacity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for zero capacity")
+		}
+	}()
+	NewQueue[int](0)
+}
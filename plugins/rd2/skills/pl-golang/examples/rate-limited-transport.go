@@ -0,0 +1,27 @@
+package examples
+
+import "net/http"
+
+// RateLimitedTransport is an http.RoundTripper that waits for a token from
+// Limiter before delegating to Base. Waiting is abandoned if the request's
+// context is canceled.
+type RateLimitedTransport struct {
+	Base    http.RoundTripper // nil means http.DefaultTransport
+	Limiter *RateLimiter
+}
+
+// RoundTrip implements http.RoundTripper. As the interface requires, it
+// closes the request body even when it gives up before sending.
+func (t *RateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.Limiter.Wait(req.Context()); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
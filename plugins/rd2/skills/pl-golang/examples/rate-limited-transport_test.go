@@ -0,0 +1,94 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitedTransportThrottles(t *testing.T) {
+	var hits atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer srv.Close()
+
+	// 20 req/s with a burst of 1: 6 requests need at least 5 refills (250ms).
+	client := &http.Client{Transport: &RateLimitedTransport{Limiter: NewRateLimiter(20, 1)}}
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+	elapsed := time.Since(start)
+
+	if hits.Load() != 6 {
+		t.Errorf("expected 6 requests to reach the server, got %d", hits.Load())
+	}
+	if elapsed < 240*time.Millisecond {
+		t.Errorf("requests exceeded the configured rate: 6 in %v", elapsed)
+	}
+}
+
+func TestRateLimitedTransportCanceledWait(t *testing.T) {
+	var hits atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer srv.Close()
+
+	limiter := NewRateLimiter(0.1, 1)
+	_ = limiter.Allow()
+	client := &http.Client{Transport: &RateLimitedTransport{Limiter: limiter}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+
+	_, err := client.Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if hits.Load() != 0 {
+		t.Errorf("expected no request to reach the server, got %d", hits.Load())
+	}
+}
+
+// closeTrackingBody records whether Close was called.
+type closeTrackingBody struct {
+	io.Reader
+	closed atomic.Bool
+}
+
+func (b *closeTrackingBody) Close() error {
+	b.closed.Store(true)
+	return nil
+}
+
+func TestRateLimitedTransportClosesBodyOnWaitError(t *testing.T) {
+	limiter := NewRateLimiter(0.1, 1)
+	_ = limiter.Allow()
+	transport := &RateLimitedTransport{Limiter: limiter}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	body := &closeTrackingBody{Reader: strings.NewReader("payload")}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.invalid", body)
+
+	if _, err := transport.RoundTrip(req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected Canceled, got %v", err)
+	}
+	if !body.closed.Load() {
+		t.Error("expected the request body to be closed when the wait fails")
+	}
+}
@@ -0,0 +1,78 @@
+package examples
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket: it holds up to burst tokens and refills at
+// rate tokens per second. Each request consumes one token.
+type RateLimiter struct {
+	mu     sync.Mutex
+	clock  Clock
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a limiter that starts with a full bucket. It panics
+// if rate is not positive or burst is below 1, since Wait could then never
+// succeed.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return newRateLimiterClock(rate, burst, RealClock)
+}
+
+func newRateLimiterClock(rate float64, burst int, clock Clock) *RateLimiter {
+	if !(rate > 0) || burst < 1 {
+		panic("examples: rate limiter needs a positive rate and burst")
+	}
+	return &RateLimiter{
+		clock:  clock,
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   clock.Now(),
+	}
+}
+
+// Allow consumes a token if one is available, without blocking.
+func (l *RateLimiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(l.clock.Now())
+	if l.tokens >= 1 {
+		l.tokens--
+		return true
+	}
+	return false
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		l.refill(l.clock.Now())
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		if err := sleepClock(ctx, l.clock, wait); err != nil {
+			return err
+		}
+	}
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+	l.tokens += elapsed * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
@@ -0,0 +1,70 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterAllowBurst(t *testing.T) {
+	l := NewRateLimiter(1, 3)
+
+	for i := 0; i < 3; i++ {
+		if !l.Allow() {
+			t.Fatalf("expected request %d within burst to be allowed", i)
+		}
+	}
+	if l.Allow() {
+		t.Error("expected request beyond burst to be rejected")
+	}
+}
+
+func TestRateLimiterWaitRefills(t *testing.T) {
+	l := NewRateLimiter(50, 1)
+	_ = l.Allow()
+
+	start := time.Now()
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("expected Wait to block for a refill, took %v", elapsed)
+	}
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	l := NewRateLimiter(0.1, 1)
+	_ = l.Allow()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestNewRateLimiterInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		rate  float64
+		burst int
+	}{
+		{"zero rate", 0, 1},
+		{"negative rate", -1, 1},
+		{"NaN rate", math.NaN(), 1},
+		{"zero burst", 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for rate %v, burst %d", tt.rate, tt.burst)
+				}
+			}()
+			NewRateLimiter(tt.rate, tt.burst)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package examples
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Readiness coordinates service startup: each dependency registers before
+// it starts initializing and reports Ready when done, and WaitReady blocks
+// until every registered dependency has, e.g. before a readiness probe
+// starts returning 200.
+type Readiness struct {
+	mu      sync.Mutex
+	cv      *CondVar
+	pending map[string]bool // registered names; true once ready
+	order   []string
+}
+
+// NewReadiness returns a Readiness with no dependencies.
+func NewReadiness() *Readiness {
+	r := &Readiness{pending: make(map[string]bool)}
+	r.cv = NewCondVar(&r.mu)
+	return r
+}
+
+// Register adds a dependency that WaitReady must wait for. Registering a
+// name twice has no effect.
+func (r *Readiness) Register(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.pending[name]; !ok {
+		r.pending[name] = false
+		r.order = append(r.order, name)
+	}
+}
+
+// Ready marks the dependency as initialized, registering it if needed.
+func (r *Readiness) Ready(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.pending[name]; !ok {
+		r.order = append(r.order, name)
+	}
+	r.pending[name] = true
+	r.cv.Broadcast()
+}
+
+// WaitReady blocks until every registered dependency is ready. If ctx is
+// done first, the error names the dependencies still missing and wraps
+// ctx.Err().
+func (r *Readiness) WaitReady(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for {
+		missing := r.missing()
+		if len(missing) == 0 {
+			return nil
+		}
+		if err := r.cv.Wait(ctx); err != nil {
+			return fmt.Errorf("not ready: %s: %w", strings.Join(r.missing(), ", "), err)
+		}
+	}
+}
+
+// missing returns the registered names not yet ready, in registration order.
+func (r *Readiness) missing() []string {
+	var names []string
+	for _, name := range r.order {
+		if !r.pending[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,59 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadinessWaitsForAll(t *testing.T) {
+	r := NewReadiness()
+	r.Register("db")
+	r.Register("cache")
+
+	done := make(chan error, 1)
+	go func() { done <- r.WaitReady(context.Background()) }()
+
+	r.Ready("db")
+	select {
+	case err := <-done:
+		t.Fatalf("expected WaitReady to block until cache is ready, got %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	r.Ready("cache")
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected WaitReady to return once all deps are ready")
+	}
+}
+
+func TestReadinessNoDependencies(t *testing.T) {
+	if err := NewReadiness().WaitReady(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReadinessReportsMissing(t *testing.T) {
+	r := NewReadiness()
+	r.Register("db")
+	r.Register("queue")
+	r.Register("cache")
+	r.Ready("db")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := r.WaitReady(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "queue, cache") || strings.Contains(msg, "db") {
+		t.Errorf("expected error to name queue and cache only, got %q", msg)
+	}
+}
@@ -0,0 +1,40 @@
+package examples
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// RecoverMiddleware converts a panic in the handler into a clean 500 JSON
+// response written by WriteError. The panic value and stack are logged with
+// the request ID but never sent to the client. A nil logger skips logging.
+func RecoverMiddleware(logger *slog.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				v := recover()
+				if v == nil {
+					return
+				}
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+
+				if logger != nil {
+					id, _ := RequestID(r.Context())
+					logger.ErrorContext(r.Context(), "panic recovered",
+						slog.String("request_id", id),
+						slog.String("method", r.Method),
+						slog.String("path", r.URL.Path),
+						slog.String("panic", fmt.Sprint(v)),
+						slog.String("stack", string(debug.Stack())),
+					)
+				}
+				WriteError(w, NewCoded(CodeInternal, "internal error"))
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package examples
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecoverMiddlewarePanic(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+
+	handler := RecoverMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("nil map write in checkout")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/checkout", nil)
+	req = req.WithContext(WithRequestID(req.Context(), "req-42"))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rec.Code)
+	}
+	var body ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("expected JSON body: %v", err)
+	}
+	if body.Code != "Internal" || body.Message != "internal error" {
+		t.Errorf("unexpected body: %+v", body)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("expected one JSON log entry: %v\n%s", err, logs.String())
+	}
+	if entry["request_id"] != "req-42" {
+		t.Errorf("expected request_id req-42 in log, got %v", entry["request_id"])
+	}
+	if entry["panic"] != "nil map write in checkout" {
+		t.Errorf("expected panic value in log, got %v", entry["panic"])
+	}
+	if stack, _ := entry["stack"].(string); !strings.Contains(stack, "TestRecoverMiddlewarePanic") {
+		t.Errorf("expected stack trace in log, got %q", stack)
+	}
+}
+
+func TestRecoverMiddlewareHidesPanicDetails(t *testing.T) {
+	handler := RecoverMiddleware(slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("token=abc123")
+		}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if strings.Contains(rec.Body.String(), "abc123") {
+		t.Errorf("panic details leaked to client: %s", rec.Body.String())
+	}
+}
+
+func TestRecoverMiddlewarePassThrough(t *testing.T) {
+	var logs bytes.Buffer
+	handler := RecoverMiddleware(slog.New(slog.NewTextHandler(&logs, nil)))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("ok"))
+		}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	if rec.Code != http.StatusCreated || rec.Body.String() != "ok" {
+		t.Errorf("expected handler response unchanged, got %d %q", rec.Code, rec.Body.String())
+	}
+	if logs.Len() != 0 {
+		t.Errorf("expected no logs for a normal request, got %s", logs.String())
+	}
+}
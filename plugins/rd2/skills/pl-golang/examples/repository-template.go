@@ -0,0 +1,103 @@
+package examples
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ErrNotFound is returned by repositories when no entity has the given ID.
+var ErrNotFound = errors.New("not found")
+
+// Repository is a generic data-access interface. Every method takes a
+// context so implementations backed by a database can honor cancellation.
+type Repository[T any, ID comparable] interface {
+	Get(ctx context.Context, id ID) (T, error)
+	List(ctx context.Context) ([]T, error)
+	Save(ctx context.Context, entity T) error
+	Delete(ctx context.Context, id ID) error
+}
+
+// MemoryRepository is an in-memory Repository, useful as a test double and
+// as a starting point before a real store exists.
+type MemoryRepository[T any, ID cmp.Ordered] struct {
+	mu    sync.RWMutex
+	items map[ID]T
+	idOf  func(T) ID
+}
+
+var _ Repository[User, int64] = (*MemoryRepository[User, int64])(nil)
+
+// NewMemoryRepository returns an empty repository; idOf extracts an entity's
+// ID.
+func NewMemoryRepository[T any, ID cmp.Ordered](idOf func(T) ID) *MemoryRepository[T, ID] {
+	return &MemoryRepository[T, ID]{items: make(map[ID]T), idOf: idOf}
+}
+
+// Get returns the entity with id, or an error wrapping ErrNotFound.
+func (r *MemoryRepository[T, ID]) Get(ctx context.Context, id ID) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	v, ok := r.items[id]
+	if !ok {
+		return zero, fmt.Errorf("get %v: %w", id, ErrNotFound)
+	}
+	return v, nil
+}
+
+// List returns all entities ordered by ID.
+func (r *MemoryRepository[T, ID]) List(ctx context.Context) ([]T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]ID, 0, len(r.items))
+	for id := range r.items {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	out := make([]T, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, r.items[id])
+	}
+	return out, nil
+}
+
+// Save inserts or replaces entity.
+func (r *MemoryRepository[T, ID]) Save(ctx context.Context, entity T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items[r.idOf(entity)] = entity
+	return nil
+}
+
+// Delete removes the entity with id, or returns an error wrapping
+// ErrNotFound.
+func (r *MemoryRepository[T, ID]) Delete(ctx context.Context, id ID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.items[id]; !ok {
+		return fmt.Errorf("delete %v: %w", id, ErrNotFound)
+	}
+	delete(r.items, id)
+	return nil
+}
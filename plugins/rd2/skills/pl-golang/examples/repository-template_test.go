@@ -0,0 +1,78 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func newUserRepo() *MemoryRepository[User, int64] {
+	return NewMemoryRepository(func(u User) int64 { return u.ID })
+}
+
+func TestMemoryRepositoryCRUD(t *testing.T) {
+	ctx := context.Background()
+	repo := newUserRepo()
+
+	for _, u := range []User{{ID: 2, Name: "bob"}, {ID: 1, Name: "ann"}} {
+		if err := repo.Save(ctx, u); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	got, err := repo.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Name != "ann" {
+		t.Errorf("expected ann, got %q", got.Name)
+	}
+
+	// Save replaces an existing entity.
+	_ = repo.Save(ctx, User{ID: 1, Name: "anne"})
+	list, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "anne" || list[1].Name != "bob" {
+		t.Errorf("expected [anne bob] ordered by ID, got %+v", list)
+	}
+
+	if err := repo.Delete(ctx, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list, _ := repo.List(ctx); len(list) != 1 {
+		t.Errorf("expected 1 entity after delete, got %d", len(list))
+	}
+}
+
+func TestMemoryRepositoryNotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := newUserRepo()
+
+	if _, err := repo.Get(ctx, 99); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get: expected ErrNotFound, got %v", err)
+	}
+	if err := repo.Delete(ctx, 99); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete: expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestMemoryRepositoryContextCanceled(t *testing.T) {
+	repo := newUserRepo()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := repo.Save(ctx, User{ID: 1}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Save: expected Canceled, got %v", err)
+	}
+	if _, err := repo.Get(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("Get: expected Canceled, got %v", err)
+	}
+	if _, err := repo.List(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("List: expected Canceled, got %v", err)
+	}
+	if err := repo.Delete(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("Delete: expected Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package examples
+
+import (
+	"context"
+	"sync"
+)
+
+type requestCache struct {
+	mu     sync.Mutex
+	values map[string]any
+}
+
+var requestCacheKey = NewContextKey[*requestCache]("request-cache")
+
+// WithCache returns a child context carrying an empty per-request cache.
+// Install it once at the edge of a request (e.g. in middleware) so repeated
+// lookups during that request share results.
+func WithCache(ctx context.Context) context.Context {
+	return requestCacheKey.WithValue(ctx, &requestCache{values: make(map[string]any)})
+}
+
+// CacheGetOrLoad returns the value cached under key in ctx's request cache,
+// calling load and caching its result on a miss. Errors are not cached.
+// Without a cache in ctx, load is called every time.
+//
+// Concurrent misses for the same key within one request may each call load;
+// put a single-flight in front of load if that matters.
+func CacheGetOrLoad[V any](ctx context.Context, key string, load func() (V, error)) (V, error) {
+	c, ok := requestCacheKey.Value(ctx)
+	if !ok {
+		return load()
+	}
+
+	c.mu.Lock()
+	cached, hit := c.values[key].(V)
+	c.mu.Unlock()
+	if hit {
+		return cached, nil
+	}
+
+	v, err := load()
+	if err != nil {
+		return v, err
+	}
+	c.mu.Lock()
+	c.values[key] = v
+	c.mu.Unlock()
+	return v, nil
+}
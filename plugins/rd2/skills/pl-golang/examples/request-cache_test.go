@@ -0,0 +1,83 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCacheGetOrLoadOncePerKey(t *testing.T) {
+	ctx := WithCache(context.Background())
+
+	loads := map[string]int{}
+	loader := func(key string) func() (string, error) {
+		return func() (string, error) {
+			loads[key]++
+			return "value-" + key, nil
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		for _, key := range []string{"user:1", "user:2"} {
+			got, err := CacheGetOrLoad(ctx, key, loader(key))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != "value-"+key {
+				t.Errorf("expected value-%s, got %q", key, got)
+			}
+		}
+	}
+
+	if loads["user:1"] != 1 || loads["user:2"] != 1 {
+		t.Errorf("expected one load per key, got %v", loads)
+	}
+}
+
+func TestCacheGetOrLoadFreshContext(t *testing.T) {
+	calls := 0
+	load := func() (int, error) {
+		calls++
+		return calls, nil
+	}
+
+	first, _ := CacheGetOrLoad(WithCache(context.Background()), "k", load)
+	second, _ := CacheGetOrLoad(WithCache(context.Background()), "k", load)
+
+	if first != 1 || second != 2 {
+		t.Errorf("expected each request to start empty, got %d and %d", first, second)
+	}
+}
+
+func TestCacheGetOrLoadErrorsNotCached(t *testing.T) {
+	ctx := WithCache(context.Background())
+	calls := 0
+	load := func() (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, errors.New("timeout")
+		}
+		return 7, nil
+	}
+
+	if _, err := CacheGetOrLoad(ctx, "k", load); err == nil {
+		t.Fatal("expected first load to fail")
+	}
+	if got, err := CacheGetOrLoad(ctx, "k", load); err != nil || got != 7 {
+		t.Errorf("expected (7, nil) on retry, got (%d, %v)", got, err)
+	}
+}
+
+func TestCacheGetOrLoadWithoutCache(t *testing.T) {
+	calls := 0
+	load := func() (int, error) {
+		calls++
+		return calls, nil
+	}
+
+	_, _ = CacheGetOrLoad(context.Background(), "k", load)
+	_, _ = CacheGetOrLoad(context.Background(), "k", load)
+	if calls != 2 {
+		t.Errorf("expected load on every call without a cache, got %d", calls)
+	}
+}
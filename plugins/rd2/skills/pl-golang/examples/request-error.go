@@ -0,0 +1,55 @@
+package examples
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// RequestInfo identifies the HTTP request an error occurred in.
+type RequestInfo struct {
+	Method    string
+	Path      string
+	RequestID string
+}
+
+// LogValue lets RequestInfo be passed directly as a slog attribute.
+func (i RequestInfo) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("method", i.Method),
+		slog.String("path", i.Path),
+		slog.String("request_id", i.RequestID),
+	)
+}
+
+type requestError struct {
+	err  error
+	info RequestInfo
+}
+
+func (e *requestError) Error() string { return e.err.Error() }
+
+func (e *requestError) Unwrap() error { return e.err }
+
+// WithRequest annotates err with r's method, path, and request ID (see
+// WithRequestID). It returns nil if err is nil.
+func WithRequest(err error, r *http.Request) error {
+	if err == nil {
+		return nil
+	}
+	id, _ := RequestID(r.Context())
+	return &requestError{err: err, info: RequestInfo{
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		RequestID: id,
+	}}
+}
+
+// RequestInfoOf returns the outermost RequestInfo attached to err's chain.
+func RequestInfoOf(err error) (RequestInfo, bool) {
+	var re *requestError
+	if errors.As(err, &re) {
+		return re.info, true
+	}
+	return RequestInfo{}, false
+}
@@ -0,0 +1,57 @@
+package examples
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestInfoOf(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/orders/7?debug=1", nil)
+	r = r.WithContext(WithRequestID(r.Context(), "req-9"))
+	base := errors.New("insufficient stock")
+
+	tests := []struct {
+		name string
+		err  error
+		ok   bool
+	}{
+		{"attached", WithRequest(base, r), true},
+		{"wrapped", fmt.Errorf("place order: %w", WithRequest(base, r)), true},
+		{"plain error", base, false},
+		{"nil", nil, false},
+	}
+
+	want := RequestInfo{Method: http.MethodPost, Path: "/orders/7", RequestID: "req-9"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, ok := RequestInfoOf(tt.err)
+			if ok != tt.ok {
+				t.Fatalf("expected ok=%v, got %v", tt.ok, ok)
+			}
+			if ok && info != want {
+				t.Errorf("expected %+v, got %+v", want, info)
+			}
+		})
+	}
+}
+
+func TestWithRequestPreservesChain(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	err := WithRequest(ErrNotFound, r)
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("expected errors.Is to see the wrapped error")
+	}
+	if err.Error() != ErrNotFound.Error() {
+		t.Errorf("expected message unchanged, got %q", err.Error())
+	}
+	if info, _ := RequestInfoOf(err); info.RequestID != "" {
+		t.Errorf("expected empty request ID without WithRequestID, got %q", info.RequestID)
+	}
+	if WithRequest(nil, r) != nil {
+		t.Error("expected nil for nil error")
+	}
+}
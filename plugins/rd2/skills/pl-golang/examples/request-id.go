@@ -0,0 +1,50 @@
+package examples
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader carries the request ID on requests and responses.
+const RequestIDHeader = "X-Request-ID"
+
+var requestIDKey = NewContextKey[string]("request-id")
+
+// WithRequestID returns a child context carrying the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return requestIDKey.WithValue(ctx, id)
+}
+
+// RequestID returns the request ID stored by WithRequestID.
+func RequestID(ctx context.Context) (string, bool) {
+	id, ok := requestIDKey.Value(ctx)
+	return id, ok && id != ""
+}
+
+// maxRequestIDLen bounds caller-supplied IDs so they cannot bloat logs.
+const maxRequestIDLen = 128
+
+// RequestIDMiddleware stores a request ID in the request context and echoes
+// it in the response's X-Request-ID header. A caller-supplied X-Request-ID is
+// reused so IDs follow a request across services; otherwise a random one is
+// generated.
+func RequestIDMiddleware() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if id == "" || len(id) > maxRequestIDLen {
+				id = newRequestID()
+			}
+			w.Header().Set(RequestIDHeader, id)
+			next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), id)))
+		})
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
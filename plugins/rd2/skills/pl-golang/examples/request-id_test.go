@@ -0,0 +1,61 @@
+package examples
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestID(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "req-123")
+	if id, ok := RequestID(ctx); !ok || id != "req-123" {
+		t.Errorf("expected (req-123, true), got (%q, %v)", id, ok)
+	}
+
+	if _, ok := RequestID(context.Background()); ok {
+		t.Error("expected no request ID on a bare context")
+	}
+	if _, ok := RequestID(WithRequestID(context.Background(), "")); ok {
+		t.Error("expected empty request ID to count as missing")
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	long := strings.Repeat("x", maxRequestIDLen+1)
+
+	tests := []struct {
+		name     string
+		incoming string
+		reused   bool
+	}{
+		{"generated", "", false},
+		{"propagated", "upstream-7", true},
+		{"oversized replaced", long, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			handler := RequestIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen, _ = RequestID(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(RequestIDHeader, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			header := rec.Header().Get(RequestIDHeader)
+			if seen == "" || header != seen {
+				t.Fatalf("expected header %q to match context ID %q", header, seen)
+			}
+			if reused := seen == tt.incoming; reused != tt.reused {
+				t.Errorf("expected reused=%v, got ID %q", tt.reused, seen)
+			}
+		})
+	}
+}
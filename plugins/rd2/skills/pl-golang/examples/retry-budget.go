@@ -0,0 +1,54 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrRetryBudgetExhausted is wrapped into Retry's error when the request's
+// RetryBudget stopped it from retrying.
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// RetryBudget caps the retries one request may make across every operation
+// it performs. Without it, a request calling several flaky dependencies,
+// each retried independently, multiplies load exactly when the system is
+// least able to take it. It is safe for concurrent use.
+type RetryBudget struct {
+	remaining atomic.Int64
+}
+
+var retryBudgetKey = NewContextKey[*RetryBudget]("retry-budget")
+
+// WithRetryBudget returns a child context carrying a fresh budget of n
+// retries, replacing any budget ctx already has. Retry consults it before
+// every retry; first attempts are never charged.
+func WithRetryBudget(ctx context.Context, n int) context.Context {
+	b := &RetryBudget{}
+	b.remaining.Store(int64(n))
+	return retryBudgetKey.WithValue(ctx, b)
+}
+
+// RetryBudgetFrom returns the budget stored by WithRetryBudget.
+func RetryBudgetFrom(ctx context.Context) (*RetryBudget, bool) {
+	b, ok := retryBudgetKey.Value(ctx)
+	return b, ok && b != nil
+}
+
+// Remaining reports how many retries are left.
+func (b *RetryBudget) Remaining() int {
+	return int(max(b.remaining.Load(), 0))
+}
+
+// take spends one retry, reporting false if none are left.
+func (b *RetryBudget) take() bool {
+	for {
+		n := b.remaining.Load()
+		if n <= 0 {
+			return false
+		}
+		if b.remaining.CompareAndSwap(n, n-1) {
+			return true
+		}
+	}
+}
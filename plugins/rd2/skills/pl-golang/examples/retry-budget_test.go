@@ -0,0 +1,70 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryBudgetSharedAcrossOperations(t *testing.T) {
+	ctx := WithRetryBudget(context.Background(), 3)
+	cfg := BackoffConfig{MaxAttempts: 5, Initial: time.Millisecond}
+
+	calls := 0
+	countFailing := func() error {
+		calls++
+		return errDependency
+	}
+
+	// The first operation spends the whole budget: 1 attempt + 3 retries.
+	err := Retry(ctx, cfg, countFailing)
+	if !errors.Is(err, ErrRetryBudgetExhausted) || !errors.Is(err, errDependency) {
+		t.Fatalf("expected budget exhaustion wrapping the last error, got %v", err)
+	}
+	if calls != 4 {
+		t.Errorf("expected 4 calls, got %d", calls)
+	}
+
+	// A second operation in the same request gets no retries.
+	calls = 0
+	if err := Retry(ctx, cfg, countFailing); !errors.Is(err, ErrRetryBudgetExhausted) {
+		t.Errorf("expected budget exhaustion, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected only the first attempt, got %d calls", calls)
+	}
+
+	// Successful first attempts cost nothing.
+	if err := Retry(ctx, cfg, func() error { return nil }); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRetryBudgetFreshContext(t *testing.T) {
+	cfg := BackoffConfig{MaxAttempts: 3, Initial: time.Millisecond}
+	spent := WithRetryBudget(context.Background(), 1)
+	_ = Retry(spent, cfg, failing)
+	if b, _ := RetryBudgetFrom(spent); b.Remaining() != 0 {
+		t.Fatalf("expected budget spent, %d left", b.Remaining())
+	}
+
+	// A new request, or a new budget layered on top, starts full.
+	fresh := WithRetryBudget(spent, 2)
+	b, ok := RetryBudgetFrom(fresh)
+	if !ok || b.Remaining() != 2 {
+		t.Fatalf("expected fresh budget of 2, got %v", b)
+	}
+	calls := 0
+	err := Retry(fresh, cfg, func() error {
+		calls++
+		return errDependency
+	})
+	if errors.Is(err, ErrRetryBudgetExhausted) || calls != 3 {
+		t.Errorf("expected all 3 attempts under the fresh budget, got %d calls and %v", calls, err)
+	}
+
+	if _, ok := RetryBudgetFrom(context.Background()); ok {
+		t.Error("expected no budget on a plain context")
+	}
+}
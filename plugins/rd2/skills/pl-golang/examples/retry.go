@@ -0,0 +1,74 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// BackoffConfig controls exponential backoff between retry attempts.
+type BackoffConfig struct {
+	MaxAttempts int           // total attempts, including the first; < 1 means 1
+	Initial     time.Duration // delay after the first failure
+	Max         time.Duration // upper bound for any delay; 0 means no bound
+	Multiplier  float64       // growth factor per attempt; values < 1 mean 2
+}
+
+// Delay returns the wait after the given zero-based failed attempt.
+func (c BackoffConfig) Delay(attempt int) time.Duration {
+	mult := c.Multiplier
+	if mult < 1 {
+		mult = 2
+	}
+	d := float64(c.Initial)
+	for i := 0; i < attempt; i++ {
+		d *= mult
+		if c.Max > 0 && d >= float64(c.Max) {
+			return c.Max
+		}
+	}
+	return time.Duration(d)
+}
+
+// Retry calls fn until it succeeds, MaxAttempts is reached, or ctx is done,
+// sleeping with backoff between attempts. If ctx carries a RetryBudget, each
+// retry spends from it and retrying stops once it is empty.
+func Retry(ctx context.Context, cfg BackoffConfig, fn func() error) error {
+	budget, _ := RetryBudgetFrom(ctx)
+	attempts := max(cfg.MaxAttempts, 1)
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			if budget != nil && !budget.take() {
+				return fmt.Errorf("%w after %d attempts: %w", ErrRetryBudgetExhausted, attempt, lastErr)
+			}
+			if err := Sleep(ctx, cfg.Delay(attempt-1)); err != nil {
+				return errors.Join(err, lastErr)
+			}
+		}
+		if lastErr = fn(); lastErr == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("after %d attempts: %w", attempts, lastErr)
+}
+
+// RetryWithBreaker is Retry guarded by a CircuitBreaker: each attempt goes
+// through breaker, and once the breaker rejects a call the loop stops and
+// returns ErrCircuitOpen instead of hammering a failing dependency.
+func RetryWithBreaker(ctx context.Context, breaker *CircuitBreaker, cfg BackoffConfig, fn func() error) error {
+	var lastErr error
+	err := Retry(ctx, cfg, func() error {
+		err := breaker.Execute(fn)
+		if errors.Is(err, ErrCircuitOpen) {
+			lastErr = err
+			return nil // stop retrying; reported below
+		}
+		return err
+	})
+	if lastErr != nil {
+		return lastErr
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackoffConfigDelay(t *testing.T) {
+	cfg := BackoffConfig{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}
+
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 200 * time.Millisecond},
+		{2, 400 * time.Millisecond},
+		{3, 800 * time.Millisecond},
+		{4, time.Second},
+		{10, time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := cfg.Delay(tt.attempt); got != tt.expected {
+			t.Errorf("Delay(%d) = %v, want %v", tt.attempt, got, tt.expected)
+		}
+	}
+}
+
+func TestRetrySucceedsAfterFailures(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), BackoffConfig{MaxAttempts: 5, Initial: time.Millisecond}, func() error {
+		calls++
+		if calls < 3 {
+			return errDependency
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), BackoffConfig{MaxAttempts: 3, Initial: time.Millisecond}, func() error {
+		calls++
+		return errDependency
+	})
+
+	if !errors.Is(err, errDependency) {
+		t.Errorf("expected last error to be wrapped, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryZeroConfig(t *testing.T) {
+	calls := 0
+	err := Retry(context.Background(), BackoffConfig{}, func() error {
+		calls++
+		return errDependency
+	})
+
+	if calls != 1 {
+		t.Errorf("expected a zero config to make 1 attempt, got %d", calls)
+	}
+	if !errors.Is(err, errDependency) || strings.Contains(err.Error(), "%!") {
+		t.Errorf("expected the attempt's error to be wrapped, got %v", err)
+	}
+	if err := Retry(context.Background(), BackoffConfig{}, func() error { return nil }); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := Retry(ctx, BackoffConfig{MaxAttempts: 10, Initial: time.Hour}, failing)
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, errDependency) {
+		t.Errorf("expected deadline and last error, got %v", err)
+	}
+}
+
+func TestRetryWithBreakerOpensAndFailsFast(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	breaker := NewCircuitBreaker(3, time.Minute, clock)
+	cfg := BackoffConfig{MaxAttempts: 10, Initial: time.Millisecond}
+
+	calls := 0
+	flaky := func() error {
+		calls++
+		return errDependency
+	}
+
+	err := RetryWithBreaker(context.Background(), breaker, cfg, flaky)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen once the breaker trips, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected the breaker to stop retries after 3 calls, got %d", calls)
+	}
+
+	// While open, calls fail fast without touching the dependency.
+	err = RetryWithBreaker(context.Background(), breaker, cfg, flaky)
+	if !errors.Is(err, ErrCircuitOpen) || calls != 3 {
+		t.Errorf("expected fast failure, got %v after %d calls", err, calls)
+	}
+
+	// After the cooldown a healthy dependency is reached again.
+	clock.Advance(time.Minute)
+	if err := RetryWithBreaker(context.Background(), breaker, cfg, succeeding); err != nil {
+		t.Errorf("expected success after cooldown, got %v", err)
+	}
+	if breaker.State() != StateClosed {
+		t.Errorf("expected breaker to close, got %v", breaker.State())
+	}
+}
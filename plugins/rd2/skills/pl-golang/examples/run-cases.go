@@ -0,0 +1,28 @@
+package examples
+
+import "testing"
+
+// RunCases runs each case as a parallel subtest named by nameFn. Every
+// subtest receives its own copy of the case, so there is no need for the
+// `tt := tt` capture idiom and no shared loop variable to mutate.
+//
+// Example:
+//
+//	RunCases(t, tests, func(tc divideCase) string { return tc.name },
+//		func(t *testing.T, tc divideCase) {
+//			got, err := Divide(tc.a, tc.b)
+//			...
+//		})
+func RunCases[C any](t *testing.T, cases []C, nameFn func(C) string, run func(*testing.T, C)) {
+	t.Helper()
+	for _, c := range cases {
+		t.Run(nameFn(c), parallelCase(c, run))
+	}
+}
+
+func parallelCase[C any](c C, run func(*testing.T, C)) func(*testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+		run(t, c)
+	}
+}
@@ -0,0 +1,71 @@
+package examples
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type divideCase struct {
+	name     string
+	a, b     int
+	expected int
+	scratch  []int
+}
+
+func TestRunCasesRunsAll(t *testing.T) {
+	cases := []divideCase{
+		{name: "even", a: 10, b: 2, expected: 5},
+		{name: "truncates", a: 7, b: 2, expected: 3},
+		{name: "negative", a: -9, b: 3, expected: -3},
+	}
+
+	var ran atomic.Int64
+	var mu sync.Mutex
+	seen := map[string]bool{}
+
+	// Parallel subtests finish before the enclosing t.Run returns.
+	t.Run("group", func(t *testing.T) {
+		RunCases(t, cases, func(c divideCase) string { return c.name }, func(t *testing.T, c divideCase) {
+			ran.Add(1)
+			mu.Lock()
+			seen[c.name] = true
+			mu.Unlock()
+			if got := c.a / c.b; got != c.expected {
+				t.Errorf("%d / %d = %d, want %d", c.a, c.b, got, c.expected)
+			}
+		})
+	})
+
+	if ran.Load() != int64(len(cases)) {
+		t.Errorf("expected %d cases to run, got %d", len(cases), ran.Load())
+	}
+	for _, c := range cases {
+		if !seen[c.name] {
+			t.Errorf("case %q did not run", c.name)
+		}
+	}
+}
+
+func TestRunCasesIsolatesState(t *testing.T) {
+	cases := make([]divideCase, 8)
+	for i := range cases {
+		cases[i] = divideCase{name: fmt.Sprint("case", i), a: i}
+	}
+
+	RunCases(t, cases, func(c divideCase) string { return c.name }, func(t *testing.T, c divideCase) {
+		// Mutate the case copy while other subtests run in parallel; each
+		// must only ever observe its own value.
+		for j := 0; j < 5; j++ {
+			c.scratch = append(c.scratch, c.a)
+			time.Sleep(time.Millisecond)
+		}
+		for _, v := range c.scratch {
+			if v != c.a {
+				t.Errorf("case %s observed foreign value %d", c.name, v)
+			}
+		}
+	})
+}
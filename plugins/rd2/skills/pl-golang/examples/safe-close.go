@@ -0,0 +1,18 @@
+package examples
+
+// SafeClose closes ch unless it is already closed and reports whether this
+// call closed it, so goroutines racing to finish can all call it. Prefer a
+// single owner or sync.Once where the design allows; SafeClose is for when
+// it does not. Other panics, such as closing a nil channel, propagate.
+func SafeClose[T any](ch chan T) (closed bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if err, ok := r.(error); !ok || err.Error() != "close of closed channel" {
+				panic(r)
+			}
+			closed = false
+		}
+	}()
+	close(ch)
+	return true
+}
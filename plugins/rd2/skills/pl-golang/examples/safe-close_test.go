@@ -0,0 +1,49 @@
+package examples
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSafeClose(t *testing.T) {
+	ch := make(chan int)
+	if !SafeClose(ch) {
+		t.Error("expected first close to report true")
+	}
+	if SafeClose(ch) {
+		t.Error("expected second close to report false")
+	}
+	if _, ok := <-ch; ok {
+		t.Error("expected channel to be closed")
+	}
+}
+
+func TestSafeCloseConcurrent(t *testing.T) {
+	ch := make(chan struct{})
+	var closers atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if SafeClose(ch) {
+				closers.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := closers.Load(); n != 1 {
+		t.Errorf("expected exactly one closer, got %d", n)
+	}
+}
+
+func TestSafeCloseNilPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected closing a nil channel to panic")
+		}
+	}()
+	SafeClose[int](nil)
+}
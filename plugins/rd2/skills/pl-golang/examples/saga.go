@@ -0,0 +1,54 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Saga runs a sequence of steps that cannot share a transaction, such as
+// calls to separate services. Each step pairs an action with a compensation
+// that undoes it; if a step fails, the compensations of the steps that
+// completed run in reverse order.
+type Saga struct {
+	steps []sagaStep
+}
+
+type sagaStep struct {
+	do, undo func(ctx context.Context) error
+}
+
+// AddStep appends a step. undo may be nil for steps with nothing to undo.
+func (s *Saga) AddStep(do, undo func(ctx context.Context) error) {
+	s.steps = append(s.steps, sagaStep{do, undo})
+}
+
+// Execute runs the steps in order, stopping at the first failure or when
+// ctx is done. It then compensates the completed steps, under a context
+// that is not canceled with ctx so cleanup still runs, and returns the
+// step's error joined with any compensation errors.
+func (s *Saga) Execute(ctx context.Context) error {
+	for i, step := range s.steps {
+		err := ctx.Err()
+		if err == nil {
+			err = step.do(ctx)
+		}
+		if err != nil {
+			return errors.Join(fmt.Errorf("saga step %d: %w", i, err), s.compensate(context.WithoutCancel(ctx), i))
+		}
+	}
+	return nil
+}
+
+// compensate undoes the first n steps, last first, continuing past failures.
+func (s *Saga) compensate(ctx context.Context, n int) error {
+	var errs []error
+	for i := n - 1; i >= 0; i-- {
+		if undo := s.steps[i].undo; undo != nil {
+			if err := undo(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("compensate step %d: %w", i, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,89 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// recordingSaga builds a saga whose steps log to calls; failDo and failUndo
+// name the steps whose action or compensation fails.
+func recordingSaga(n int, failDo, failUndo map[int]error) (*Saga, *[]string) {
+	var calls []string
+	s := &Saga{}
+	for i := 0; i < n; i++ {
+		s.AddStep(
+			func(context.Context) error {
+				calls = append(calls, fmt.Sprintf("do %d", i))
+				return failDo[i]
+			},
+			func(context.Context) error {
+				calls = append(calls, fmt.Sprintf("undo %d", i))
+				return failUndo[i]
+			},
+		)
+	}
+	return s, &calls
+}
+
+func TestSagaSuccess(t *testing.T) {
+	s, calls := recordingSaga(3, nil, nil)
+	if err := s.Execute(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"do 0", "do 1", "do 2"}; !slices.Equal(*calls, want) {
+		t.Errorf("expected %v without compensation, got %v", want, *calls)
+	}
+}
+
+func TestSagaCompensatesInReverse(t *testing.T) {
+	errPayment := errors.New("payment declined")
+	s, calls := recordingSaga(4, map[int]error{2: errPayment}, nil)
+
+	err := s.Execute(context.Background())
+	if !errors.Is(err, errPayment) {
+		t.Fatalf("expected step error, got %v", err)
+	}
+	if want := []string{"do 0", "do 1", "do 2", "undo 1", "undo 0"}; !slices.Equal(*calls, want) {
+		t.Errorf("expected %v, got %v", want, *calls)
+	}
+}
+
+func TestSagaCompensationError(t *testing.T) {
+	errStep := errors.New("step failed")
+	errUndo := errors.New("refund failed")
+	s, calls := recordingSaga(3, map[int]error{2: errStep}, map[int]error{1: errUndo})
+
+	err := s.Execute(context.Background())
+	if !errors.Is(err, errStep) || !errors.Is(err, errUndo) {
+		t.Errorf("expected step and compensation errors joined, got %v", err)
+	}
+	// A failed compensation does not stop the remaining ones.
+	if want := []string{"do 0", "do 1", "do 2", "undo 1", "undo 0"}; !slices.Equal(*calls, want) {
+		t.Errorf("expected %v, got %v", want, *calls)
+	}
+}
+
+func TestSagaCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	undone := false
+	var undoErr error
+	s := &Saga{}
+	s.AddStep(func(context.Context) error { cancel(); return nil }, func(ctx context.Context) error {
+		undone, undoErr = true, ctx.Err()
+		return nil
+	})
+	s.AddStep(func(context.Context) error {
+		t.Error("expected step not to run after cancel")
+		return nil
+	}, nil)
+
+	if err := s.Execute(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Canceled, got %v", err)
+	}
+	if !undone || undoErr != nil {
+		t.Errorf("expected completed step compensated with a live context, got (%v, %v)", undone, undoErr)
+	}
+}
@@ -0,0 +1,52 @@
+package examples
+
+import "context"
+
+// Semaphore bounds how many goroutines may hold a slot at once.
+type Semaphore struct {
+	slots chan struct{}
+}
+
+// NewSemaphore returns a Semaphore with n slots. It panics if n is not
+// positive.
+func NewSemaphore(n int) *Semaphore {
+	if n <= 0 {
+		panic("examples: semaphore size must be positive")
+	}
+	return &Semaphore{slots: make(chan struct{}, n)}
+}
+
+// Acquire takes a slot, blocking until one is free or ctx is done.
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TryAcquire takes a slot if one is free without blocking.
+func (s *Semaphore) TryAcquire() bool {
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release frees a slot taken by Acquire or TryAcquire. It panics if no slot
+// is held.
+func (s *Semaphore) Release() {
+	select {
+	case <-s.slots:
+	default:
+		panic("examples: semaphore released without acquire")
+	}
+}
+
+// InUse reports how many slots are currently held.
+func (s *Semaphore) InUse() int {
+	return len(s.slots)
+}
@@ -0,0 +1,41 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSemaphoreBounds(t *testing.T) {
+	sem := NewSemaphore(2)
+	if !sem.TryAcquire() || !sem.TryAcquire() {
+		t.Fatal("expected two slots to be free")
+	}
+	if sem.TryAcquire() {
+		t.Fatal("expected third TryAcquire to fail")
+	}
+	if n := sem.InUse(); n != 2 {
+		t.Errorf("expected 2 in use, got %d", n)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := sem.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+
+	sem.Release()
+	if err := sem.Acquire(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSemaphoreReleaseWithoutAcquirePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	NewSemaphore(1).Release()
+}
@@ -0,0 +1,53 @@
+package examples
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Server runs an http.Server until a context is canceled, then shuts it
+// down gracefully: the listener closes at once so new connections are
+// refused, while in-flight requests get up to GracePeriod to finish.
+type Server struct {
+	HTTP        *http.Server
+	GracePeriod time.Duration
+}
+
+// Run listens on HTTP.Addr and serves until ctx is done or serving fails.
+// It returns nil after a clean shutdown, the shutdown error (such as
+// context.DeadlineExceeded) if requests outlived the grace period, in which
+// case their connections are closed forcibly, or the listen or serve error.
+func (s *Server) Run(ctx context.Context) error {
+	addr := s.HTTP.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.serve(ctx, ln)
+}
+
+func (s *Server) serve(ctx context.Context, ln net.Listener) error {
+	errc := make(chan error, 1)
+	go func() { errc <- s.HTTP.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	// ctx is already done; the grace period needs a fresh deadline.
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.GracePeriod)
+	defer cancel()
+	err := s.HTTP.Shutdown(shutdownCtx)
+	if err != nil {
+		s.HTTP.Close()
+	}
+	<-errc // http.ErrServerClosed
+	return err
+}
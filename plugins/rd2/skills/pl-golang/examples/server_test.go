@@ -0,0 +1,113 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// startServer serves h through a Server on a loopback port and returns its
+// URL and Run's result.
+func startServer(t *testing.T, ctx context.Context, h http.Handler, grace time.Duration) (string, <-chan error) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := &Server{HTTP: &http.Server{Handler: h}, GracePeriod: grace}
+	errc := make(chan error, 1)
+	go func() { errc <- s.serve(ctx, ln) }()
+	return "http://" + ln.Addr().String(), errc
+}
+
+func TestServerGracefulShutdown(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			close(entered)
+			<-release
+		}
+		io.WriteString(w, "done")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	url, errc := startServer(t, ctx, h, 5*time.Second)
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+
+	type result struct {
+		body string
+		err  error
+	}
+	inFlight := make(chan result, 1)
+	go func() {
+		resp, err := client.Get(url + "/slow")
+		if err != nil {
+			inFlight <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		inFlight <- result{string(b), err}
+	}()
+	<-entered
+	cancel()
+
+	// New requests are refused once the listener is closed.
+	err := PollUntil(context.Background(), time.Millisecond, 10*time.Millisecond, func(context.Context) (bool, error) {
+		resp, err := client.Get(url + "/")
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err != nil, nil
+	})
+	if err != nil {
+		t.Fatalf("expected requests after shutdown to be rejected: %v", err)
+	}
+
+	close(release)
+	if r := <-inFlight; r.err != nil || r.body != "done" {
+		t.Errorf("expected in-flight request to complete, got (%q, %v)", r.body, r.err)
+	}
+	if err := <-errc; err != nil {
+		t.Errorf("expected clean shutdown, got %v", err)
+	}
+}
+
+func TestServerGracePeriodExceeded(t *testing.T) {
+	entered := make(chan struct{})
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	url, errc := startServer(t, ctx, h, 20*time.Millisecond)
+	go func() {
+		if resp, err := http.Get(url); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-entered
+	cancel()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Run to return after the grace period")
+	}
+}
+
+func TestServerRunListenError(t *testing.T) {
+	s := &Server{HTTP: &http.Server{Addr: "127.0.0.1:-1"}}
+	if err := s.Run(context.Background()); err == nil {
+		t.Error("expected listen error for invalid address")
+	}
+}
@@ -0,0 +1,25 @@
+package examples
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// SignalContext returns a context canceled when one of sig arrives, or
+// SIGINT/SIGTERM if none are given. Call the returned cancel func (typically
+// deferred in main) to stop listening and restore default signal handling,
+// so a second Ctrl-C kills a process stuck in shutdown.
+//
+// Example:
+//
+//	ctx, stop := SignalContext()
+//	defer stop()
+//	if err := srv.Run(ctx); err != nil { ... }
+func SignalContext(sig ...os.Signal) (context.Context, context.CancelFunc) {
+	if len(sig) == 0 {
+		sig = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	return signal.NotifyContext(context.Background(), sig...)
+}
@@ -0,0 +1,47 @@
+//go:build unix
+
+package examples
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestSignalContext(t *testing.T) {
+	tests := []struct {
+		name string
+		sigs []os.Signal
+		send syscall.Signal
+	}{
+		{"default SIGTERM", nil, syscall.SIGTERM},
+		{"custom SIGUSR1", []os.Signal{syscall.SIGUSR1}, syscall.SIGUSR1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, stop := SignalContext(tt.sigs...)
+			defer stop()
+
+			if err := syscall.Kill(syscall.Getpid(), tt.send); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+				t.Fatal("context not canceled after signal")
+			}
+		})
+	}
+}
+
+func TestSignalContextStop(t *testing.T) {
+	ctx, stop := SignalContext()
+	stop()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("expected Canceled after stop, got %v", ctx.Err())
+	}
+}
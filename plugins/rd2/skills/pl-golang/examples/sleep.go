@@ -0,0 +1,28 @@
+package examples
+
+import (
+	"context"
+	"time"
+)
+
+// Sleep pauses for d, returning ctx.Err() as soon as ctx is done. Unlike
+// time.Sleep it can be interrupted, and unlike time.After it stops its timer
+// on the early return.
+func Sleep(ctx context.Context, d time.Duration) error {
+	return sleepClock(ctx, RealClock, d)
+}
+
+func sleepClock(ctx context.Context, clock Clock, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := clock.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,81 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSleepCompletes(t *testing.T) {
+	start := time.Now()
+	if err := Sleep(context.Background(), 20*time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("returned after %v, before the full duration", elapsed)
+	}
+}
+
+func TestSleepCanceledEarly(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := Sleep(ctx, time.Minute)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("cancellation took too long: %v", elapsed)
+	}
+}
+
+func TestSleepNonPositive(t *testing.T) {
+	if err := Sleep(context.Background(), 0); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Sleep(ctx, -time.Second); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Canceled, got %v", err)
+	}
+}
+
+func TestSleepStopsTimerOnCancel(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() { done <- sleepClock(ctx, clock, time.Hour) }()
+
+	clock.BlockUntil(1)
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected Canceled, got %v", err)
+	}
+	if n := clock.Pending(); n != 0 {
+		t.Errorf("expected timer to be stopped, %d still pending", n)
+	}
+}
+
+func TestSleepFakeClockFullDuration(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+
+	done := make(chan error, 1)
+	go func() { done <- sleepClock(context.Background(), clock, time.Minute) }()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("sleep did not return after the clock advanced")
+	}
+}
@@ -0,0 +1,331 @@
+package examples
+
+import "context"
+
+// GroupBy partitions in by keyFn. Elements keep their relative order within
+// each group.
+func GroupBy[T any, K comparable](in []T, keyFn func(T) K) map[K][]T {
+	out := make(map[K][]T)
+	for _, v := range in {
+		k := keyFn(v)
+		out[k] = append(out[k], v)
+	}
+	return out
+}
+
+// Chunk splits in into consecutive sub-slices of at most size elements, e.g.
+// to batch database writes or API calls. The chunks share in's backing array
+// but are capacity-limited, so appending to one never clobbers the next.
+// A size <= 0 is treated as "no limit" and yields in as a single chunk; empty
+// input yields nil.
+func Chunk[T any](in []T, size int) [][]T {
+	if len(in) == 0 {
+		return nil
+	}
+	if size <= 0 {
+		return [][]T{in[:len(in):len(in)]}
+	}
+
+	out := make([][]T, 0, (len(in)+size-1)/size)
+	for start := 0; start < len(in); start += size {
+		end := min(start+size, len(in))
+		out = append(out, in[start:end:end])
+	}
+	return out
+}
+
+// Distribute splits in into exactly n buckets (at least 1) of as-equal-as-
+// possible size, e.g. to shard work across n workers; sizes differ by at
+// most one, with earlier buckets taking the extras. Unlike Chunk, which fixes
+// the size and lets the count vary, Distribute fixes the count, so buckets
+// beyond len(in) are nil. Buckets share in's backing array but are
+// capacity-limited, as with Chunk.
+func Distribute[T any](in []T, n int) [][]T {
+	n = max(n, 1)
+	out := make([][]T, n)
+	size, extra := len(in)/n, len(in)%n
+	start := 0
+	for i := range out {
+		end := start + size
+		if i < extra {
+			end++
+		}
+		if end > start {
+			out[i] = in[start:end:end]
+		}
+		start = end
+	}
+	return out
+}
+
+// Partition splits in into the elements that satisfy pred and those that do
+// not, preserving order in both.
+func Partition[T any](in []T, pred func(T) bool) (matched, rest []T) {
+	for _, v := range in {
+		if pred(v) {
+			matched = append(matched, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	return matched, rest
+}
+
+// ToMap indexes in by keyFn for fast lookups. When several elements share a
+// key, the last one wins.
+func ToMap[T any, K comparable](in []T, keyFn func(T) K) map[K]T {
+	out := make(map[K]T, len(in))
+	for _, v := range in {
+		out[keyFn(v)] = v
+	}
+	return out
+}
+
+// Reduce folds in into a single value, starting from init.
+func Reduce[T, R any](in []T, init R, fn func(acc R, item T) R) R {
+	acc := init
+	for _, v := range in {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// ReduceE is Reduce for fallible accumulations. It stops at the first error
+// and returns it along with the accumulator as it stood before the failing
+// item.
+func ReduceE[T, R any](in []T, init R, fn func(acc R, item T) (R, error)) (R, error) {
+	acc := init
+	for _, v := range in {
+		next, err := fn(acc, v)
+		if err != nil {
+			return acc, err
+		}
+		acc = next
+	}
+	return acc, nil
+}
+
+// Pair holds two related values, e.g. an input and its result.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip pairs a[i] with b[i], truncating to the shorter slice.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	n := min(len(a), len(b))
+	if n == 0 {
+		return nil
+	}
+	out := make([]Pair[A, B], n)
+	for i := range out {
+		out[i] = Pair[A, B]{First: a[i], Second: b[i]}
+	}
+	return out
+}
+
+// Unzip splits pairs back into two parallel slices.
+func Unzip[A, B any](pairs []Pair[A, B]) ([]A, []B) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	as := make([]A, len(pairs))
+	bs := make([]B, len(pairs))
+	for i, p := range pairs {
+		as[i], bs[i] = p.First, p.Second
+	}
+	return as, bs
+}
+
+// Flatten concatenates the nested slices of in, in order. The result is
+// allocated once at its final size.
+func Flatten[T any](in [][]T) []T {
+	n := 0
+	for _, s := range in {
+		n += len(s)
+	}
+	if n == 0 {
+		return nil
+	}
+	out := make([]T, 0, n)
+	for _, s := range in {
+		out = append(out, s...)
+	}
+	return out
+}
+
+// FlatMap maps each element of in to a slice with fn and concatenates the
+// results in order, e.g. expanding orders into their line items.
+func FlatMap[T, R any](in []T, fn func(T) []R) []R {
+	var out []R
+	for _, v := range in {
+		out = append(out, fn(v)...)
+	}
+	return out
+}
+
+// Reverse returns a new slice with in's elements in reverse order, leaving
+// in untouched (unlike slices.Reverse).
+func Reverse[T any](in []T) []T {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]T, len(in))
+	for i, v := range in {
+		out[len(in)-1-i] = v
+	}
+	return out
+}
+
+// Unique returns in without duplicates, keeping the first occurrence of each
+// element. Unlike slices.Compact, duplicates need not be adjacent.
+func Unique[T comparable](in []T) []T {
+	if len(in) == 0 {
+		return nil
+	}
+	seen := make(map[T]struct{}, len(in))
+	out := make([]T, 0, len(in))
+	for _, v := range in {
+		if _, dup := seen[v]; dup {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
+
+// DedupByKey returns in keeping only the first element for each key, in
+// order. It generalizes Unique to elements that are not comparable or are
+// deduplicated by a field such as an ID.
+func DedupByKey[T any, K comparable](in []T, keyFn func(T) K) []T {
+	if len(in) == 0 {
+		return nil
+	}
+	seen := make(map[K]struct{}, len(in))
+	out := make([]T, 0, len(in))
+	for _, v := range in {
+		k := keyFn(v)
+		if _, dup := seen[k]; dup {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
+
+// Find returns the first element satisfying pred, or the zero value and
+// false if there is none.
+func Find[T any](in []T, pred func(T) bool) (T, bool) {
+	if i := FindIndex(in, pred); i >= 0 {
+		return in[i], true
+	}
+	var zero T
+	return zero, false
+}
+
+// FindIndex returns the index of the first element satisfying pred, or -1.
+func FindIndex[T any](in []T, pred func(T) bool) int {
+	for i, v := range in {
+		if pred(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Interleave merges slices round-robin, taking one element from each in turn
+// and skipping slices once they are exhausted.
+func Interleave[T any](slices ...[]T) []T {
+	total, longest := 0, 0
+	for _, s := range slices {
+		total += len(s)
+		longest = max(longest, len(s))
+	}
+	if total == 0 {
+		return nil
+	}
+	out := make([]T, 0, total)
+	for i := range longest {
+		for _, s := range slices {
+			if i < len(s) {
+				out = append(out, s[i])
+			}
+		}
+	}
+	return out
+}
+
+// Every reports whether pred holds for every element of in, stopping at the
+// first that fails. It is true for an empty slice.
+func Every[T any](in []T, pred func(T) bool) bool {
+	for _, v := range in {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Some reports whether pred holds for at least one element of in, stopping
+// at the first match. It is false for an empty slice.
+func Some[T any](in []T, pred func(T) bool) bool {
+	return FindIndex(in, pred) >= 0
+}
+
+// None reports whether pred holds for no element of in, stopping at the
+// first match. It is true for an empty slice.
+func None[T any](in []T, pred func(T) bool) bool {
+	return !Some(in, pred)
+}
+
+// Count returns how many elements of in equal target.
+func Count[T comparable](in []T, target T) int {
+	return CountBy(in, func(v T) bool { return v == target })
+}
+
+// CountBy returns how many elements of in satisfy pred.
+func CountBy[T any](in []T, pred func(T) bool) int {
+	n := 0
+	for _, v := range in {
+		if pred(v) {
+			n++
+		}
+	}
+	return n
+}
+
+// ProcessChunks splits in into chunks of chunkSize (as Chunk does) and runs
+// fn on them with at most workers chunks in flight (workers <= 0 means no
+// limit), e.g. to call a batch API. Results are concatenated in chunk
+// order regardless of which finishes first. On the first error the context
+// passed to fn is canceled, no further chunks are started, and that error is
+// returned once in-flight calls finish. If ctx is done before all chunks
+// run, ctx.Err() is returned.
+func ProcessChunks[T, R any](ctx context.Context, in []T, chunkSize, workers int, fn func(context.Context, []T) ([]R, error)) ([]R, error) {
+	chunks := Chunk(in, chunkSize)
+	results := make([][]R, len(chunks))
+	g, gctx := NewGroup(ctx, workers)
+	for i, chunk := range chunks {
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			// Go may have waited for a slot while another chunk failed.
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			r, err := fn(gctx, chunk)
+			results[i] = r
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return Flatten(results), nil
+}
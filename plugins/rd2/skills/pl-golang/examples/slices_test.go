@@ -0,0 +1,622 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroupBy(t *testing.T) {
+	parity := func(n int) string {
+		if n%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	}
+
+	tests := []struct {
+		name     string
+		in       []int
+		expected map[string][]int
+	}{
+		{"multiple groups", []int{1, 2, 3, 4, 5}, map[string][]int{"odd": {1, 3, 5}, "even": {2, 4}}},
+		{"single group", []int{2, 8, 4}, map[string][]int{"even": {2, 8, 4}}},
+		{"empty input", nil, map[string][]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GroupBy(tt.in, parity)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("GroupBy(%v) = %v, want %v", tt.in, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       []int
+		size     int
+		expected [][]int
+	}{
+		{"exact division", []int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{"remainder chunk", []int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{"size larger than input", []int{1, 2}, 5, [][]int{{1, 2}}},
+		{"empty input", nil, 3, nil},
+		{"zero size is a single chunk", []int{1, 2, 3}, 0, [][]int{{1, 2, 3}}},
+		{"negative size is a single chunk", []int{1, 2, 3}, -1, [][]int{{1, 2, 3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Chunk(tt.in, tt.size)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Chunk(%v, %d) = %v, want %v", tt.in, tt.size, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDistribute(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       []int
+		n        int
+		expected [][]int
+	}{
+		{"even division", []int{1, 2, 3, 4, 5, 6}, 3, [][]int{{1, 2}, {3, 4}, {5, 6}}},
+		{"remainder to earlier buckets", []int{1, 2, 3, 4, 5, 6, 7, 8}, 3, [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8}}},
+		{"n larger than input", []int{1, 2}, 4, [][]int{{1}, {2}, nil, nil}},
+		{"empty input", nil, 2, [][]int{nil, nil}},
+		{"zero n is a single bucket", []int{1, 2, 3}, 0, [][]int{{1, 2, 3}}},
+		{"negative n is a single bucket", []int{1, 2, 3}, -2, [][]int{{1, 2, 3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Distribute(tt.in, tt.n)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Distribute(%v, %d) = %v, want %v", tt.in, tt.n, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChunkAppendDoesNotClobber(t *testing.T) {
+	in := []int{1, 2, 3, 4}
+	chunks := Chunk(in, 2)
+	_ = append(chunks[0], 99)
+	if in[2] != 3 {
+		t.Errorf("append to first chunk overwrote input: %v", in)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+
+	tests := []struct {
+		name    string
+		in      []int
+		matched []int
+		rest    []int
+	}{
+		{"mixed", []int{1, 2, 3, 4, 5}, []int{2, 4}, []int{1, 3, 5}},
+		{"all match", []int{2, 4}, []int{2, 4}, nil},
+		{"none match", []int{1, 3}, nil, []int{1, 3}},
+		{"empty input", []int{}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, rest := Partition(tt.in, even)
+			if !reflect.DeepEqual(matched, tt.matched) || !reflect.DeepEqual(rest, tt.rest) {
+				t.Errorf("Partition(%v) = %v, %v, want %v, %v", tt.in, matched, rest, tt.matched, tt.rest)
+			}
+		})
+	}
+}
+
+func TestToMap(t *testing.T) {
+	byID := func(u User) int64 { return u.ID }
+
+	tests := []struct {
+		name     string
+		in       []User
+		expected map[int64]User
+	}{
+		{
+			"unique keys",
+			[]User{{ID: 1, Name: "ann"}, {ID: 2, Name: "bob"}},
+			map[int64]User{1: {ID: 1, Name: "ann"}, 2: {ID: 2, Name: "bob"}},
+		},
+		{
+			"duplicate keys last wins",
+			[]User{{ID: 1, Name: "ann"}, {ID: 1, Name: "anna"}},
+			map[int64]User{1: {ID: 1, Name: "anna"}},
+		},
+		{"empty input", nil, map[int64]User{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToMap(tt.in, byID)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ToMap(%v) = %v, want %v", tt.in, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sum := Reduce([]int{1, 2, 3}, 10, func(acc, n int) int { return acc + n })
+	if sum != 16 {
+		t.Errorf("expected 16, got %d", sum)
+	}
+}
+
+func TestReduceE(t *testing.T) {
+	errNegative := errors.New("negative amount")
+	addPositive := func(acc, n int) (int, error) {
+		if n < 0 {
+			return 0, errNegative
+		}
+		return acc + n, nil
+	}
+
+	tests := []struct {
+		name     string
+		in       []int
+		expected int
+		err      error
+	}{
+		{"successful fold", []int{1, 2, 3}, 6, nil},
+		{"early error keeps partial", []int{1, 2, -1, 4}, 3, errNegative},
+		{"empty input returns init", nil, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReduceE(tt.in, 0, addPositive)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ReduceE(%v) = %d, want %d", tt.in, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestZipUnzip(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        []string
+		b        []int
+		expected []Pair[string, int]
+	}{
+		{"equal length", []string{"a", "b"}, []int{1, 2}, []Pair[string, int]{{"a", 1}, {"b", 2}}},
+		{"first shorter", []string{"a"}, []int{1, 2, 3}, []Pair[string, int]{{"a", 1}}},
+		{"second shorter", []string{"a", "b", "c"}, []int{1, 2}, []Pair[string, int]{{"a", 1}, {"b", 2}}},
+		{"empty input", nil, []int{1}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Zip(tt.a, tt.b)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("Zip(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.expected)
+			}
+
+			as, bs := Unzip(got)
+			n := len(tt.expected)
+			if n == 0 {
+				if as != nil || bs != nil {
+					t.Errorf("Unzip of empty = %v, %v, want nil, nil", as, bs)
+				}
+				return
+			}
+			if !reflect.DeepEqual(as, tt.a[:n]) || !reflect.DeepEqual(bs, tt.b[:n]) {
+				t.Errorf("Unzip = %v, %v, want %v, %v", as, bs, tt.a[:n], tt.b[:n])
+			}
+		})
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       [][]int
+		expected []int
+	}{
+		{"multiple slices", [][]int{{1, 2}, {3}, {4, 5}}, []int{1, 2, 3, 4, 5}},
+		{"empty inner slices", [][]int{{}, {1}, nil, {2}}, []int{1, 2}},
+		{"all empty", [][]int{{}, nil}, nil},
+		{"empty input", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Flatten(tt.in)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Flatten(%v) = %v, want %v", tt.in, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	// repeat expands n into n copies of itself.
+	repeat := func(n int) []int {
+		out := make([]int, n)
+		for i := range out {
+			out[i] = n
+		}
+		return out
+	}
+	tests := []struct {
+		name     string
+		in       []int
+		expected []int
+	}{
+		{"multiple results", []int{2, 3}, []int{2, 2, 3, 3, 3}},
+		{"zero results", []int{0, 1, 0}, []int{1}},
+		{"single results", []int{1, 1}, []int{1, 1}},
+		{"empty input", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FlatMap(tt.in, repeat); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("FlatMap(%v) = %v, want %v", tt.in, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFlattenAllocatesOnce(t *testing.T) {
+	in := Chunk(make([]int, 1000), 7)
+	if allocs := testing.AllocsPerRun(100, func() { _ = Flatten(in) }); allocs != 1 {
+		t.Errorf("expected 1 allocation, got %v", allocs)
+	}
+}
+
+// flattenNaive grows the result with append alone, for comparison.
+func flattenNaive[T any](in [][]T) []T {
+	var out []T
+	for _, s := range in {
+		out = append(out, s...)
+	}
+	return out
+}
+
+func BenchmarkFlatten(b *testing.B) {
+	in := Chunk(make([]int, 10000), 16)
+
+	b.Run("presized", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = Flatten(in)
+		}
+	})
+	b.Run("naive", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = flattenNaive(in)
+		}
+	})
+}
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       []int
+		expected []int
+	}{
+		{"several", []int{1, 2, 3}, []int{3, 2, 1}},
+		{"single element", []int{7}, []int{7}},
+		{"empty", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := slices.Clone(tt.in)
+			got := Reverse(tt.in)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Reverse(%v) = %v, want %v", tt.in, got, tt.expected)
+			}
+			if !slices.Equal(tt.in, orig) {
+				t.Errorf("Reverse modified its input: %v", tt.in)
+			}
+		})
+	}
+}
+
+func TestUnique(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       []string
+		expected []string
+	}{
+		{"keeps first occurrence", []string{"b", "a", "b", "c", "a"}, []string{"b", "a", "c"}},
+		{"no duplicates", []string{"x", "y"}, []string{"x", "y"}},
+		{"all duplicates", []string{"z", "z", "z"}, []string{"z"}},
+		{"single element", []string{"x"}, []string{"x"}},
+		{"empty", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unique(tt.in); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Unique(%v) = %v, want %v", tt.in, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDedupByKey(t *testing.T) {
+	byID := func(u User) int64 { return u.ID }
+	tests := []struct {
+		name     string
+		in       []User
+		expected []User
+	}{
+		{"distinct keys", []User{{ID: 1, Name: "ann"}, {ID: 2, Name: "bob"}}, []User{{ID: 1, Name: "ann"}, {ID: 2, Name: "bob"}}},
+		{"duplicate keys keep first", []User{{ID: 2, Name: "bob"}, {ID: 1, Name: "ann"}, {ID: 2, Name: "bobby"}}, []User{{ID: 2, Name: "bob"}, {ID: 1, Name: "ann"}}},
+		{"empty", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DedupByKey(tt.in, byID); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("DedupByKey(%v) = %v, want %v", tt.in, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	users := []User{{ID: 1, Name: "ann", Age: 17}, {ID: 2, Name: "bob", Age: 30}, {ID: 3, Name: "cy", Age: 41}}
+	adult := func(u User) bool { return u.Age >= 18 }
+
+	tests := []struct {
+		name     string
+		in       []User
+		expected User
+		index    int
+	}{
+		{"found", users, users[1], 1},
+		{"not found", users[:1], User{}, -1},
+		{"empty", nil, User{}, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Find(tt.in, adult)
+			if got != tt.expected || ok != (tt.index >= 0) {
+				t.Errorf("Find = (%+v, %v), want (%+v, %v)", got, ok, tt.expected, tt.index >= 0)
+			}
+			if i := FindIndex(tt.in, adult); i != tt.index {
+				t.Errorf("FindIndex = %d, want %d", i, tt.index)
+			}
+		})
+	}
+}
+
+func ExampleFind() {
+	data := []string{"apple", "banana", "cherry"}
+
+	v, ok := Find(data, func(s string) bool { return s == "banana" })
+	fmt.Println(v, ok)
+	_, ok = Find(data, func(s string) bool { return s == "grape" })
+	fmt.Println(ok)
+	// Output:
+	// banana true
+	// false
+}
+
+func ExampleFindIndex() {
+	data := []string{"apple", "banana", "cherry"}
+
+	fmt.Println(FindIndex(data, func(s string) bool { return s == "cherry" }))
+	fmt.Println(FindIndex(data, func(s string) bool { return s == "grape" }))
+	// Output:
+	// 2
+	// -1
+}
+
+func TestInterleave(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       [][]int
+		expected []int
+	}{
+		{"equal lengths", [][]int{{1, 2, 3}, {10, 20, 30}}, []int{1, 10, 2, 20, 3, 30}},
+		{"uneven lengths", [][]int{{1}, {10, 20, 30}, {100, 200}}, []int{1, 10, 100, 20, 200, 30}},
+		{"single slice", [][]int{{1, 2}}, []int{1, 2}},
+		{"empty slices", [][]int{nil, {}}, nil},
+		{"no slices", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Interleave(tt.in...); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Interleave(%v) = %v, want %v", tt.in, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestQuantifiers(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    []int
+		every bool
+		some  bool
+		none  bool
+		calls int // predicate calls made by Every before short-circuiting
+	}{
+		{"all match", []int{2, 4, 6}, true, true, false, 3},
+		{"some match", []int{2, 3, 4}, false, true, false, 2},
+		{"none match", []int{1, 3, 5}, false, false, true, 1},
+		{"empty", nil, true, false, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			even := func(n int) bool {
+				calls++
+				return n%2 == 0
+			}
+			if got := Every(tt.in, even); got != tt.every {
+				t.Errorf("Every(%v) = %v, want %v", tt.in, got, tt.every)
+			}
+			if calls != tt.calls {
+				t.Errorf("expected Every to make %d calls, made %d", tt.calls, calls)
+			}
+			if got := Some(tt.in, even); got != tt.some {
+				t.Errorf("Some(%v) = %v, want %v", tt.in, got, tt.some)
+			}
+			if got := None(tt.in, even); got != tt.none {
+				t.Errorf("None(%v) = %v, want %v", tt.in, got, tt.none)
+			}
+		})
+	}
+}
+
+func TestSomeShortCircuits(t *testing.T) {
+	calls := 0
+	isTwo := func(n int) bool {
+		calls++
+		return n == 2
+	}
+	if !Some([]int{1, 2, 3, 4}, isTwo) {
+		t.Fatal("expected Some to find 2")
+	}
+	if calls != 2 {
+		t.Errorf("expected Some to stop after 2 calls, made %d", calls)
+	}
+	calls = 0
+	if None([]int{2, 3, 4}, isTwo) || calls != 1 {
+		t.Errorf("expected None to stop at the first match, made %d calls", calls)
+	}
+}
+
+func TestCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     []string
+		target string
+		count  int
+		long   int // elements longer than two bytes
+	}{
+		{"multiple matches", []string{"go", "rust", "go", "zig", "go"}, "go", 3, 2},
+		{"zero matches", []string{"rust", "zig"}, "go", 0, 2},
+		{"empty", nil, "go", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Count(tt.in, tt.target); got != tt.count {
+				t.Errorf("Count(%v, %q) = %d, want %d", tt.in, tt.target, got, tt.count)
+			}
+			long := func(s string) bool { return len(s) > 2 }
+			if got := CountBy(tt.in, long); got != tt.long {
+				t.Errorf("CountBy(%v) = %d, want %d", tt.in, got, tt.long)
+			}
+		})
+	}
+}
+
+func TestProcessChunksOrder(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6, 7}
+	// Later chunks finish first; results must still follow chunk order.
+	double := func(ctx context.Context, chunk []int) ([]int, error) {
+		time.Sleep(time.Duration(10-chunk[0]) * time.Millisecond)
+		out := make([]int, len(chunk))
+		for i, v := range chunk {
+			out[i] = v * 2
+		}
+		return out, nil
+	}
+
+	got, err := ProcessChunks(context.Background(), in, 2, 4, double)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{2, 4, 6, 8, 10, 12, 14}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestProcessChunksConcurrencyCap(t *testing.T) {
+	var peaks peakTracker
+	fn := func(ctx context.Context, chunk []int) ([]int, error) {
+		defer peaks.enter()()
+		time.Sleep(5 * time.Millisecond)
+		return chunk, nil
+	}
+
+	got, err := ProcessChunks(context.Background(), make([]int, 40), 2, 3, fn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 40 {
+		t.Errorf("expected 40 results, got %d", len(got))
+	}
+	if p := peaks.Peak(); p > 3 {
+		t.Errorf("expected at most 3 chunks in flight, saw %d", p)
+	}
+}
+
+func TestProcessChunksAbortsOnError(t *testing.T) {
+	errBatch := errors.New("batch rejected")
+	var started atomic.Int32
+	fn := func(ctx context.Context, chunk []int) ([]int, error) {
+		started.Add(1)
+		if chunk[0] == 0 {
+			return nil, errBatch
+		}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	in := make([]int, 100)
+	for i := range in {
+		in[i] = i
+	}
+	got, err := ProcessChunks(context.Background(), in, 10, 2, fn)
+	if !errors.Is(err, errBatch) || got != nil {
+		t.Fatalf("expected (nil, errBatch), got (%v, %v)", got, err)
+	}
+	if n := started.Load(); n >= 10 {
+		t.Errorf("expected remaining chunks not to start, %d of 10 started", n)
+	}
+}
+
+func TestProcessChunksStartsNothingAfterFailure(t *testing.T) {
+	errBatch := errors.New("batch rejected")
+	var ran []int
+	fn := func(ctx context.Context, chunk []int) ([]int, error) {
+		ran = append(ran, chunk[0]) // workers=1, so calls never overlap
+		if chunk[0] == 2 {
+			return nil, errBatch
+		}
+		return chunk, nil
+	}
+
+	_, err := ProcessChunks(context.Background(), []int{0, 1, 2, 3, 4, 5, 6, 7}, 2, 1, fn)
+	if !errors.Is(err, errBatch) {
+		t.Fatalf("expected errBatch, got %v", err)
+	}
+	if want := []int{0, 2}; !slices.Equal(ran, want) {
+		t.Errorf("expected chunks %v to run and none after the failure, got %v", want, ran)
+	}
+}
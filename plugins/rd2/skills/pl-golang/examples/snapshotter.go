@@ -0,0 +1,41 @@
+package examples
+
+import (
+	"bytes"
+	"context"
+	"time"
+)
+
+// Snapshotter persists serialized in-memory state, such as a cache or queue
+// dump, through save. Updates are coalesced so at most one snapshot is
+// written per interval, always the latest, and a final snapshot is written
+// when ctx is done. It is a Checkpointer over byte slices that copies each
+// update, so callers may reuse their buffers.
+type Snapshotter struct {
+	c *Checkpointer[[]byte]
+}
+
+// NewSnapshotter starts a Snapshotter that writes through save.
+func NewSnapshotter(ctx context.Context, save func(context.Context, []byte) error, interval time.Duration) *Snapshotter {
+	return newSnapshotterClock(ctx, save, interval, RealClock)
+}
+
+func newSnapshotterClock(ctx context.Context, save func(context.Context, []byte) error, interval time.Duration, clock Clock) *Snapshotter {
+	return &Snapshotter{c: newCheckpointerClock(ctx, save, interval, clock)}
+}
+
+// Update records state as the latest snapshot. It never blocks on save.
+func (s *Snapshotter) Update(state []byte) {
+	s.c.Checkpoint(bytes.Clone(state))
+}
+
+// Err returns the error from the most recent save, or nil if it succeeded.
+func (s *Snapshotter) Err() error {
+	return s.c.Err()
+}
+
+// Wait blocks until the final snapshot after ctx is done has been written
+// and returns its error.
+func (s *Snapshotter) Wait() error {
+	return s.c.Wait()
+}
@@ -0,0 +1,83 @@
+package examples
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type snapshotSaver struct {
+	saved chan string
+}
+
+func (s *snapshotSaver) save(_ context.Context, state []byte) error {
+	s.saved <- string(state)
+	return nil
+}
+
+func (s *snapshotSaver) expectSave(t *testing.T, want string) {
+	t.Helper()
+	select {
+	case got := <-s.saved:
+		if got != want {
+			t.Errorf("expected snapshot %q, got %q", want, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected snapshot %q", want)
+	}
+}
+
+func (s *snapshotSaver) expectNoSave(t *testing.T) {
+	t.Helper()
+	select {
+	case got := <-s.saved:
+		t.Errorf("unexpected snapshot %q", got)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestSnapshotterIntervalWrites(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &snapshotSaver{saved: make(chan string, 10)}
+	snap := newSnapshotterClock(ctx, s.save, time.Minute, clock)
+
+	snap.Update([]byte("v1"))
+	s.expectSave(t, "v1")
+
+	// Rapid updates within an interval coalesce to the latest.
+	buf := []byte("v2")
+	snap.Update(buf)
+	copy(buf, "xx") // callers may reuse their buffer
+	snap.Update([]byte("v3"))
+	s.expectNoSave(t)
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	s.expectSave(t, "v3")
+
+	snap.Update([]byte("v4"))
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	s.expectSave(t, "v4")
+	s.expectNoSave(t)
+}
+
+func TestSnapshotterFinalWriteOnCancel(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &snapshotSaver{saved: make(chan string, 10)}
+	snap := newSnapshotterClock(ctx, s.save, time.Minute, clock)
+
+	snap.Update([]byte("v1"))
+	s.expectSave(t, "v1")
+	snap.Update([]byte("v2"))
+	clock.BlockUntil(1)
+
+	cancel()
+	if err := snap.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.expectSave(t, "v2")
+}
@@ -0,0 +1,37 @@
+package examples
+
+import (
+	"context"
+	"time"
+)
+
+// NewSteadyTicker returns a channel that receives the time every d, on a
+// fixed schedule anchored at the call: a slow consumer never pushes later
+// ticks back, it just misses the ones that fired while it was busy (at most
+// one tick is buffered). When ctx is done the underlying ticker is stopped
+// and the channel is closed, so ranging over it ends cleanly.
+func NewSteadyTicker(ctx context.Context, d time.Duration) <-chan time.Time {
+	return steadyTickerClock(ctx, RealClock, d)
+}
+
+func steadyTickerClock(ctx context.Context, clock Clock, d time.Duration) <-chan time.Time {
+	ticker := clock.NewTicker(d)
+	out := make(chan time.Time, 1)
+
+	go func() {
+		defer close(out)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case t := <-ticker.C():
+				select {
+				case out <- t:
+				default: // consumer is behind; drop this tick
+				}
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,85 @@
+package examples
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSteadyTickerSchedule(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewFakeClock(start)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ticks := steadyTickerClock(ctx, clock, time.Second)
+	for i := 1; i <= 3; i++ {
+		clock.Advance(time.Second)
+		select {
+		case got := <-ticks:
+			if want := start.Add(time.Duration(i) * time.Second); !got.Equal(want) {
+				t.Errorf("tick %d: expected %v, got %v", i, want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("tick %d not delivered", i)
+		}
+	}
+}
+
+func TestSteadyTickerDropsWithoutDrift(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewFakeClock(start)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ticks := steadyTickerClock(ctx, clock, time.Second)
+
+	// The consumer falls behind for five intervals.
+	for i := 0; i < 5; i++ {
+		clock.Advance(time.Second)
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	first := <-ticks
+	if want := start.Add(time.Second); !first.Equal(want) {
+		t.Errorf("expected first buffered tick at %v, got %v", want, first)
+	}
+	received := 1
+	for drained := false; !drained; {
+		select {
+		case <-ticks:
+			received++
+		case <-time.After(20 * time.Millisecond):
+			drained = true
+		}
+	}
+	if received >= 5 {
+		t.Errorf("expected missed ticks to be dropped, received %d", received)
+	}
+
+	// The schedule is unchanged: the next tick lands on the next whole interval.
+	clock.Advance(time.Second)
+	if got, want := <-ticks, start.Add(6*time.Second); !got.Equal(want) {
+		t.Errorf("expected next tick at %v, got %v", want, got)
+	}
+}
+
+func TestSteadyTickerCancel(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ticks := steadyTickerClock(ctx, clock, time.Second)
+	cancel()
+
+	select {
+	case _, ok := <-ticks:
+		if ok {
+			t.Fatal("expected channel to be closed, got a tick")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancellation")
+	}
+	if n := clock.Pending(); n != 0 {
+		t.Errorf("expected ticker to be stopped, %d still pending", n)
+	}
+}
@@ -0,0 +1,38 @@
+package examples
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// StreamEncode writes items to w as newline-delimited JSON, flushing after
+// each one so clients see them as they are produced. It returns nil when
+// items is closed and ctx.Err() if ctx is done first.
+func StreamEncode[T any](ctx context.Context, w http.ResponseWriter, items <-chan T) error {
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	// Send headers now so clients can start reading before the first item.
+	if err := rc.Flush(); err != nil {
+		return fmt.Errorf("flush: %w", err)
+	}
+	enc := json.NewEncoder(w)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case item, ok := <-items:
+			if !ok {
+				return nil
+			}
+			if err := enc.Encode(item); err != nil {
+				return fmt.Errorf("encode item: %w", err)
+			}
+			if err := rc.Flush(); err != nil {
+				return fmt.Errorf("flush: %w", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package examples
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type event struct {
+	Seq int `json:"seq"`
+}
+
+func TestStreamEncodeIncremental(t *testing.T) {
+	items := make(chan event)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = StreamEncode(r.Context(), w, items)
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("expected NDJSON content type, got %q", ct)
+	}
+
+	// Each item must be readable before the next one is produced.
+	r := bufio.NewReader(resp.Body)
+	for i := 1; i <= 3; i++ {
+		items <- event{Seq: i}
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			t.Fatalf("read item %d: %v", i, err)
+		}
+		var got event
+		if err := json.Unmarshal(line, &got); err != nil {
+			t.Fatalf("decode item %d: %v", i, err)
+		}
+		if got.Seq != i {
+			t.Errorf("expected seq %d, got %d", i, got.Seq)
+		}
+	}
+
+	close(items)
+	if rest, _ := io.ReadAll(r); len(rest) != 0 {
+		t.Errorf("expected end of stream, got %q", rest)
+	}
+}
+
+func TestStreamEncodeThroughMiddleware(t *testing.T) {
+	items := make(chan event, 1)
+	items <- event{Seq: 1}
+	close(items)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := Logging(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := StreamEncode(r.Context(), w, items); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !rec.Flushed {
+		t.Error("expected flush to reach the underlying writer")
+	}
+}
+
+func TestStreamEncodeCanceled(t *testing.T) {
+	items := make(chan event)
+	result := make(chan error, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result <- StreamEncode(r.Context(), w, items)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	items <- event{Seq: 1}
+	if _, err := bufio.NewReader(resp.Body).ReadBytes('\n'); err != nil {
+		t.Fatalf("read first item: %v", err)
+	}
+
+	cancel()
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stream did not stop after the client canceled")
+	}
+}
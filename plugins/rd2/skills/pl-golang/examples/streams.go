@@ -0,0 +1,360 @@
+package examples
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// recv receives from in unless ctx is done first. It reports false when in
+// is closed or ctx is done, which is when stream operators stop.
+func recv[T any](ctx context.Context, in <-chan T) (T, bool) {
+	select {
+	case v, ok := <-in:
+		return v, ok
+	case <-ctx.Done():
+		var zero T
+		return zero, false
+	}
+}
+
+// send delivers v on out unless ctx is done first.
+func send[T any](ctx context.Context, out chan<- T, v T) bool {
+	select {
+	case out <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Dedup forwards values from in, dropping any value equal to the one before
+// it (like uniq). Non-consecutive repeats pass through. The output is closed
+// when in is closed or ctx is done.
+func Dedup[T comparable](ctx context.Context, in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		var prev T
+		first := true
+		for {
+			v, ok := recv(ctx, in)
+			if !ok {
+				return
+			}
+			if !first && v == prev {
+				continue
+			}
+			first = false
+			prev = v
+			if !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Tap forwards every value from in unchanged, calling fn on each one first,
+// so logging or metrics can be attached to a pipeline without touching its
+// stages. fn runs on the operator's goroutine and should be quick. The
+// output is closed when in is closed or ctx is done.
+func Tap[T any](ctx context.Context, in <-chan T, fn func(T)) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			v, ok := recv(ctx, in)
+			if !ok {
+				return
+			}
+			fn(v)
+			if !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Accumulate emits the running result of folding each value from in into
+// acc with fn, starting from init, e.g. a prefix sum. The output is closed
+// when in is closed or ctx is done.
+func Accumulate[T any](ctx context.Context, in <-chan T, init T, fn func(acc, v T) T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		acc := init
+		for {
+			v, ok := recv(ctx, in)
+			if !ok {
+				return
+			}
+			acc = fn(acc, v)
+			if !send(ctx, out, acc) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// TakeWhile forwards values from in while pred holds and closes the output
+// at the first value for which it does not; that value is dropped. It stops
+// reading in at that point, so cancel ctx to release the producer. The
+// output is also closed when in is closed or ctx is done.
+func TakeWhile[T any](ctx context.Context, in <-chan T, pred func(T) bool) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			v, ok := recv(ctx, in)
+			if !ok || !pred(v) {
+				return
+			}
+			if !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// DropWhile discards the leading values from in that fail pred, then
+// forwards the first value that satisfies it and everything after it,
+// without calling pred again, e.g. skipping a stream's preamble up to the
+// first record of interest. The output is closed when in is closed or ctx
+// is done.
+func DropWhile[T any](ctx context.Context, in <-chan T, pred func(T) bool) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		dropping := true
+		for {
+			v, ok := recv(ctx, in)
+			if !ok {
+				return
+			}
+			if dropping && !pred(v) {
+				continue
+			}
+			dropping = false
+			if !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Windowed emits a sliding window of the last size values (at least 1) each
+// time a value arrives, starting once size values have been seen, e.g. for
+// moving averages. Each window is a fresh slice the receiver may keep. The
+// output is closed when in is closed or ctx is done.
+func Windowed[T any](ctx context.Context, in <-chan T, size int) <-chan []T {
+	size = max(size, 1)
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		window := make([]T, 0, size)
+		for {
+			v, ok := recv(ctx, in)
+			if !ok {
+				return
+			}
+			if len(window) == size {
+				window = append(window[:0], window[1:]...)
+			}
+			window = append(window, v)
+			if len(window) < size {
+				continue
+			}
+			if !send(ctx, out, slices.Clone(window)) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// ChunkByKey groups runs of consecutive values with the same key, emitting
+// each run when the key changes and the last run when in closes, like
+// grouping a stream sorted by that key. Values with a key seen earlier but
+// not adjacent start a new run. The output is closed after that, or when
+// ctx is done, in which case the pending run is dropped.
+func ChunkByKey[T any, K comparable](ctx context.Context, in <-chan T, keyFn func(T) K) <-chan []T {
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		var run []T
+		var key K
+		for {
+			v, ok := recv(ctx, in)
+			if !ok {
+				if len(run) > 0 && ctx.Err() == nil {
+					send(ctx, out, run)
+				}
+				return
+			}
+			k := keyFn(v)
+			if len(run) > 0 && k != key {
+				if !send(ctx, out, run) {
+					return
+				}
+				run = nil
+			}
+			key = k
+			run = append(run, v)
+		}
+	}()
+	return out
+}
+
+// Batch groups values from in into slices of up to size (at least 1). A
+// partial batch is emitted once maxWait passes without a new value, and
+// whatever is left is emitted when in closes. The output is closed after
+// that, or when ctx is done, in which case a pending partial batch is
+// dropped.
+func Batch[T any](ctx context.Context, in <-chan T, size int, maxWait time.Duration) <-chan []T {
+	return batchClock(ctx, RealClock, in, size, maxWait)
+}
+
+func batchClock[T any](ctx context.Context, clock Clock, in <-chan T, size int, maxWait time.Duration) <-chan []T {
+	size = max(size, 1)
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		var buf []T
+		var timer Timer
+		var idle <-chan time.Time
+		stopTimer := func() {
+			if timer != nil && !timer.Stop() {
+				select {
+				case <-timer.C():
+				default:
+				}
+			}
+			idle = nil
+		}
+		defer stopTimer()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					if len(buf) > 0 {
+						send(ctx, out, buf)
+					}
+					return
+				}
+				buf = append(buf, v)
+				stopTimer()
+				if len(buf) < size {
+					if timer == nil {
+						timer = clock.NewTimer(maxWait)
+					} else {
+						timer.Reset(maxWait)
+					}
+					idle = timer.C()
+					continue
+				}
+			case <-idle:
+				idle = nil
+			}
+
+			if !send(ctx, out, buf) {
+				return
+			}
+			buf = nil
+		}
+	}()
+	return out
+}
+
+// SplitByKey routes each value from in to a per-key output channel, keeping
+// input order within a key, so each partition can be consumed by its own
+// worker. The returned func gives the channel for a key, creating it on
+// demand; call it for a key before or after values with that key arrive.
+// Output channels are unbuffered and routing is sequential, so every key
+// that appears must be drained or the whole split stalls. All channels,
+// including ones requested later, are closed when in is closed or ctx is
+// done.
+func SplitByKey[T any, K comparable](ctx context.Context, in <-chan T, keyFn func(T) K) func(K) <-chan T {
+	var (
+		mu     sync.Mutex
+		outs   = make(map[K]chan T)
+		closed bool
+	)
+	get := func(k K) chan T {
+		mu.Lock()
+		defer mu.Unlock()
+		ch, ok := outs[k]
+		if !ok {
+			ch = make(chan T)
+			if closed {
+				close(ch)
+			}
+			outs[k] = ch
+		}
+		return ch
+	}
+
+	go func() {
+		defer func() {
+			mu.Lock()
+			defer mu.Unlock()
+			closed = true
+			for _, ch := range outs {
+				close(ch)
+			}
+		}()
+		for {
+			v, ok := recv(ctx, in)
+			if !ok {
+				return
+			}
+			if !send(ctx, get(keyFn(v)), v) {
+				return
+			}
+		}
+	}()
+	return func(k K) <-chan T { return get(k) }
+}
+
+// Labeled is a value tagged with the label of the source it came from.
+type Labeled[T any, L comparable] struct {
+	Label L
+	Value T
+}
+
+// LabeledFanIn merges sources into one stream, tagging each value with its
+// source's label. Order is preserved within a source but not across
+// sources. The output is closed once every source is closed, or when ctx is
+// done.
+func LabeledFanIn[T any, L comparable](ctx context.Context, sources map[L]<-chan T) <-chan Labeled[T, L] {
+	out := make(chan Labeled[T, L])
+	var wg sync.WaitGroup
+	for label, in := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				v, ok := recv(ctx, in)
+				if !ok {
+					return
+				}
+				if !send(ctx, out, Labeled[T, L]{Label: label, Value: v}) {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
@@ -0,0 +1,381 @@
+package examples
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDedup(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       []string
+		expected []string
+	}{
+		{"consecutive duplicates", []string{"up", "up", "up", "down", "down", "up"}, []string{"up", "down", "up"}},
+		{"non-consecutive pass", []string{"a", "b", "a", "b"}, []string{"a", "b", "a", "b"}},
+		{"zero value first", []string{"", "", "x"}, []string{"", "x"}},
+		{"empty", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			got := Collect(Dedup(ctx, Generate(ctx, tt.in...)))
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDedupCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := Dedup(ctx, in)
+
+	in <- 1
+	AssertRecv(t, out, 1, time.Second)
+
+	cancel()
+	AssertChanClosed(t, out, time.Second)
+}
+
+func TestTap(t *testing.T) {
+	ctx := context.Background()
+	var seen []int
+	got := Collect(Tap(ctx, Generate(ctx, 3, 1, 2), func(v int) { seen = append(seen, v) }))
+
+	if want := []int{3, 1, 2}; !slices.Equal(got, want) {
+		t.Errorf("expected forwarded %v, got %v", want, got)
+	}
+	if !slices.Equal(seen, got) {
+		t.Errorf("expected fn called once per value in order, saw %v", seen)
+	}
+}
+
+func TestTapCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := Tap(ctx, in, func(int) {})
+
+	in <- 1
+	AssertRecv(t, out, 1, time.Second)
+
+	cancel()
+	AssertChanClosed(t, out, time.Second)
+}
+
+func TestAccumulate(t *testing.T) {
+	ctx := context.Background()
+	sum := func(acc, v int) int { return acc + v }
+
+	got := Collect(Accumulate(ctx, Generate(ctx, 1, 2, 3, 4), 10, sum))
+	if want := []int{11, 13, 16, 20}; !slices.Equal(got, want) {
+		t.Errorf("expected running totals %v, got %v", want, got)
+	}
+	if got := Collect(Accumulate(ctx, Generate[int](ctx), 0, sum)); len(got) != 0 {
+		t.Errorf("expected nothing for empty input, got %v", got)
+	}
+}
+
+func TestAccumulateCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := Accumulate(ctx, in, 0, func(acc, v int) int { return acc + v })
+
+	in <- 5
+	AssertRecv(t, out, 5, time.Second)
+
+	cancel()
+	AssertChanClosed(t, out, time.Second)
+}
+
+func TestChunkByKey(t *testing.T) {
+	ctx := context.Background()
+	firstLetter := func(s string) byte { return s[0] }
+	in := Generate(ctx, "ant", "ape", "bee", "cat", "cow", "crow", "asp")
+
+	got := Collect(ChunkByKey(ctx, in, firstLetter))
+	want := [][]string{{"ant", "ape"}, {"bee"}, {"cat", "cow", "crow"}, {"asp"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("expected runs %v, got %v", want, got)
+	}
+}
+
+func TestChunkByKeyFlushesOnKeyChange(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	in := make(chan int)
+	out := ChunkByKey(ctx, in, func(v int) int { return v / 10 })
+
+	in <- 11
+	in <- 12
+	in <- 21 // the key change releases the first run without waiting for more
+	select {
+	case got := <-out:
+		if want := []int{11, 12}; !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected run to be emitted on key change")
+	}
+
+	close(in)
+	select {
+	case got := <-out:
+		if want := []int{21}; !slices.Equal(got, want) {
+			t.Errorf("expected final run %v, got %v", want, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected final run on close")
+	}
+	AssertChanClosed(t, out, time.Second)
+}
+
+func TestTakeWhileDropWhile(t *testing.T) {
+	small := func(v int) bool { return v < 3 }
+	tests := []struct {
+		name string
+		in   []int
+		take []int
+		drop []int
+	}{
+		{"true to false", []int{1, 2, 3, 1, 4}, []int{1, 2}, []int{1, 2, 3, 1, 4}},
+		{"false to true", []int{3, 4, 1, 5}, nil, []int{1, 5}},
+		{"all true", []int{1, 2, 1}, []int{1, 2, 1}, []int{1, 2, 1}},
+		{"all false", []int{3, 4}, nil, nil},
+		{"empty", nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel() // releases Generate after TakeWhile stops reading
+
+			if got := Collect(TakeWhile(ctx, Generate(ctx, tt.in...), small)); !slices.Equal(got, tt.take) {
+				t.Errorf("TakeWhile(%v) = %v, want %v", tt.in, got, tt.take)
+			}
+			if got := Collect(DropWhile(ctx, Generate(ctx, tt.in...), small)); !slices.Equal(got, tt.drop) {
+				t.Errorf("DropWhile(%v) = %v, want %v", tt.in, got, tt.drop)
+			}
+		})
+	}
+}
+
+func TestTakeWhileDropWhileCanceled(t *testing.T) {
+	always := func(int) bool { return true }
+	ctx, cancel := context.WithCancel(context.Background())
+	in1, in2 := make(chan int), make(chan int)
+	take, drop := TakeWhile(ctx, in1, always), DropWhile(ctx, in2, always)
+
+	in1 <- 1
+	AssertRecv(t, take, 1, time.Second)
+	in2 <- 1
+	AssertRecv(t, drop, 1, time.Second)
+
+	cancel()
+	AssertChanClosed(t, take, time.Second)
+	AssertChanClosed(t, drop, time.Second)
+}
+
+func TestBatchBySize(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ctx := context.Background()
+	out := batchClock(ctx, clock, Generate(ctx, 1, 2, 3, 4, 5, 6, 7), 3, time.Minute)
+
+	var got [][]int
+	for b := range out {
+		got = append(got, b)
+	}
+	want := [][]int{{1, 2, 3}, {4, 5, 6}, {7}} // the last is the final flush
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Errorf("batch %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestBatchByTime(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	in := make(chan int)
+	out := batchClock(ctx, clock, in, 10, time.Second)
+
+	in <- 1
+	in <- 2
+	clock.BlockUntil(1)
+	select {
+	case b := <-out:
+		t.Fatalf("unexpected batch before maxWait: %v", b)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case b := <-out:
+		if !slices.Equal(b, []int{1, 2}) {
+			t.Errorf("expected [1 2], got %v", b)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a partial batch after maxWait")
+	}
+
+	// Closing the input flushes what is left.
+	in <- 3
+	close(in)
+	if b := <-out; !slices.Equal(b, []int{3}) {
+		t.Errorf("expected final batch [3], got %v", b)
+	}
+	AssertChanClosed(t, out, time.Second)
+}
+
+func TestBatchCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := Batch(ctx, in, 10, time.Hour)
+
+	in <- 1
+	cancel()
+	AssertChanClosed(t, out, time.Second)
+}
+
+func TestWindowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       []int
+		size     int
+		expected [][]int
+	}{
+		{"sliding", []int{1, 2, 3, 4, 5}, 3, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}},
+		{"size one", []int{1, 2}, 1, [][]int{{1}, {2}}},
+		{"fewer than size", []int{1, 2}, 3, nil},
+		{"empty", nil, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			got := Collect(Windowed(ctx, Generate(ctx, tt.in...), tt.size))
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+			for i := range got {
+				if !slices.Equal(got[i], tt.expected[i]) {
+					t.Errorf("window %d: expected %v, got %v", i, tt.expected[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWindowedIncremental(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	in := make(chan float64)
+	out := Windowed(ctx, in, 2)
+
+	in <- 10
+	select {
+	case w := <-out:
+		t.Fatalf("unexpected window before size values: %v", w)
+	case in <- 20:
+	}
+	if w := <-out; !slices.Equal(w, []float64{10, 20}) {
+		t.Errorf("expected [10 20], got %v", w)
+	}
+	in <- 30
+	if w := <-out; !slices.Equal(w, []float64{20, 30}) {
+		t.Errorf("expected [20 30], got %v", w)
+	}
+
+	close(in)
+	AssertChanClosed(t, out, time.Second)
+}
+
+func TestSplitByKey(t *testing.T) {
+	ctx := context.Background()
+	parity := func(v int) int { return v % 2 }
+	split := SplitByKey(ctx, Generate(ctx, 1, 2, 3, 4, 5, 6, 7), parity)
+
+	results := make(chan []int, 2)
+	for _, k := range []int{0, 1} {
+		ch := split(k)
+		go func() { results <- Collect(ch) }()
+	}
+	got := [][]int{<-results, <-results}
+	slices.SortFunc(got, func(a, b []int) int { return a[0] - b[0] })
+
+	if want := []int{1, 3, 5, 7}; !slices.Equal(got[0], want) {
+		t.Errorf("expected odd partition %v, got %v", want, got[0])
+	}
+	if want := []int{2, 4, 6}; !slices.Equal(got[1], want) {
+		t.Errorf("expected even partition %v, got %v", want, got[1])
+	}
+
+	// Same key, same channel; keys never seen get an already-closed one.
+	if split(0) != split(0) {
+		t.Error("expected the same channel for the same key")
+	}
+	AssertChanClosed(t, split(42), time.Second)
+}
+
+func TestSplitByKeyCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan string)
+	split := SplitByKey(ctx, in, func(s string) byte { return s[0] })
+
+	a := split('a')
+	in <- "apple"
+	AssertRecv(t, a, "apple", time.Second)
+
+	cancel()
+	AssertChanClosed(t, a, time.Second)
+	AssertChanClosed(t, split('b'), time.Second)
+}
+
+func TestLabeledFanIn(t *testing.T) {
+	ctx := context.Background()
+	got := Collect(LabeledFanIn(ctx, map[string]<-chan int{
+		"odd":  Generate(ctx, 1, 3, 5),
+		"even": Generate(ctx, 2, 4),
+	}))
+
+	bySource := map[string][]int{}
+	for _, l := range got {
+		bySource[l.Label] = append(bySource[l.Label], l.Value)
+	}
+	if want := []int{1, 3, 5}; !slices.Equal(bySource["odd"], want) {
+		t.Errorf("expected odd source %v in order, got %v", want, bySource["odd"])
+	}
+	if want := []int{2, 4}; !slices.Equal(bySource["even"], want) {
+		t.Errorf("expected even source %v in order, got %v", want, bySource["even"])
+	}
+	if len(bySource) != 2 {
+		t.Errorf("expected only known labels, got %v", bySource)
+	}
+}
+
+func TestLabeledFanInWaitsForAllSources(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	a, b := make(chan int), make(chan int)
+	out := LabeledFanIn(ctx, map[string]<-chan int{"a": a, "b": b})
+
+	close(a)
+	b <- 7 // b still flows after a is drained
+	AssertRecv(t, out, Labeled[int, string]{Label: "b", Value: 7}, time.Second)
+
+	close(b)
+	AssertChanClosed(t, out, time.Second)
+
+	// Cancellation also closes the output while sources are open.
+	c := make(chan int)
+	out = LabeledFanIn(ctx, map[string]<-chan int{"c": c})
+	cancel()
+	AssertChanClosed(t, out, time.Second)
+}
@@ -0,0 +1,175 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExceeded is returned when a TimeoutTree child would take the
+// total allocation past the tree's budget.
+var ErrBudgetExceeded = errors.New("timeout budget exceeded")
+
+// TimeoutTree splits one operation's latency budget among named steps, so an
+// end-to-end SLO is enforced across a call graph instead of each step picking
+// its own timeout. Child allocations may not sum to more than the budget, and
+// no child deadline extends past the root's.
+//
+// Example:
+//
+//	tree := NewTimeoutTree(ctx, 800*time.Millisecond)
+//	defer tree.Cancel()
+//	authCtx, done, err := tree.Child("auth", 100*time.Millisecond)
+type TimeoutTree struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	budget time.Duration
+	start  time.Time
+	clock  Clock
+
+	mu        sync.Mutex
+	allocated time.Duration
+	used      map[string]time.Duration
+}
+
+// NewTimeoutTree returns a tree whose root context expires after budget, or
+// at parent's deadline if that comes first, in which case the budget shrinks
+// to match.
+func NewTimeoutTree(parent context.Context, budget time.Duration) *TimeoutTree {
+	return newTimeoutTreeClock(parent, budget, RealClock)
+}
+
+func newTimeoutTreeClock(parent context.Context, budget time.Duration, clock Clock) *TimeoutTree {
+	start := clock.Now()
+	if dl, ok := parent.Deadline(); ok {
+		budget = min(budget, dl.Sub(start))
+	}
+	ctx, cancel := withDeadlineClock(parent, start.Add(budget), clock)
+	return &TimeoutTree{
+		ctx:    ctx,
+		cancel: cancel,
+		budget: budget,
+		start:  start,
+		clock:  clock,
+		used:   make(map[string]time.Duration),
+	}
+}
+
+// Context returns the root context.
+func (t *TimeoutTree) Context() context.Context { return t.ctx }
+
+// Cancel releases the root context and every child.
+func (t *TimeoutTree) Cancel() { t.cancel() }
+
+// Budget returns the total budget after any clamping to the parent deadline.
+func (t *TimeoutTree) Budget() time.Duration { return t.budget }
+
+// Remaining returns the wall-clock time left before the root deadline.
+func (t *TimeoutTree) Remaining() time.Duration {
+	dl, _ := t.ctx.Deadline()
+	return max(dl.Sub(t.clock.Now()), 0)
+}
+
+// Unallocated returns the part of the budget not yet given to children.
+func (t *TimeoutTree) Unallocated() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.budget - t.allocated
+}
+
+// Child allocates d of the budget to the step name and returns its context,
+// whose deadline is d from now but never later than the root's. Call done
+// when the step finishes; it cancels the context and records the time the
+// step took under name (see Usage). Child returns an error if d is not
+// positive, ErrBudgetExceeded if the allocation does not fit, or the root's
+// error if it is already done.
+func (t *TimeoutTree) Child(name string, d time.Duration) (ctx context.Context, done func(), err error) {
+	if d <= 0 {
+		return nil, nil, fmt.Errorf("timeout tree: %s wants non-positive timeout %v", name, d)
+	}
+	if err := t.ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	t.mu.Lock()
+	if t.allocated+d > t.budget {
+		left := t.budget - t.allocated
+		t.mu.Unlock()
+		return nil, nil, fmt.Errorf("%w: %s wants %v, %v unallocated", ErrBudgetExceeded, name, d, left)
+	}
+	t.allocated += d
+	t.mu.Unlock()
+
+	start := t.clock.Now()
+	ctx, cancel := withDeadlineClock(t.ctx, start.Add(d), t.clock)
+	var once sync.Once
+	done = func() {
+		once.Do(func() {
+			cancel()
+			t.mu.Lock()
+			t.used[name] += t.clock.Now().Sub(start)
+			t.mu.Unlock()
+		})
+	}
+	return ctx, done, nil
+}
+
+// Usage returns how long each finished step took, keyed by name.
+func (t *TimeoutTree) Usage() map[string]time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make(map[string]time.Duration, len(t.used))
+	for k, v := range t.used {
+		out[k] = v
+	}
+	return out
+}
+
+// withDeadlineClock is context.WithDeadline with the deadline measured on
+// clock. For RealClock it is exactly context.WithDeadline; otherwise a clock
+// timer cancels the context, so a FakeClock can expire it on Advance.
+func withDeadlineClock(parent context.Context, deadline time.Time, clock Clock) (context.Context, context.CancelFunc) {
+	if clock == RealClock {
+		return context.WithDeadline(parent, deadline)
+	}
+	if dl, ok := parent.Deadline(); ok && dl.Before(deadline) {
+		deadline = dl
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	dctx := &clockDeadlineCtx{Context: ctx, deadline: deadline}
+	d := deadline.Sub(clock.Now())
+	if d <= 0 {
+		cancel(context.DeadlineExceeded)
+		return dctx, func() { cancel(context.Canceled) }
+	}
+
+	timer := clock.NewTimer(d)
+	go func() {
+		defer timer.Stop()
+		select {
+		case <-timer.C():
+			cancel(context.DeadlineExceeded)
+		case <-ctx.Done():
+		}
+	}()
+	return dctx, func() { cancel(context.Canceled) }
+}
+
+// clockDeadlineCtx reports a clock-measured deadline, and DeadlineExceeded
+// once it has passed, the way a context.WithDeadline context would.
+type clockDeadlineCtx struct {
+	context.Context
+	deadline time.Time
+}
+
+func (c *clockDeadlineCtx) Deadline() (time.Time, bool) { return c.deadline, true }
+
+func (c *clockDeadlineCtx) Err() error {
+	err := c.Context.Err()
+	if err != nil && errors.Is(context.Cause(c.Context), context.DeadlineExceeded) {
+		return context.DeadlineExceeded
+	}
+	return err
+}
@@ -0,0 +1,137 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeoutTreeRejectsOverAllocation(t *testing.T) {
+	tree := newTimeoutTreeClock(context.Background(), time.Second, NewFakeClock(time.Unix(0, 0)))
+	defer tree.Cancel()
+
+	steps := []struct {
+		name string
+		d    time.Duration
+		err  error
+	}{
+		{"auth", 300 * time.Millisecond, nil},
+		{"db", 500 * time.Millisecond, nil},
+		{"render", 300 * time.Millisecond, ErrBudgetExceeded},
+		{"cache", 200 * time.Millisecond, nil},
+		{"extra", time.Millisecond, ErrBudgetExceeded},
+	}
+	for _, st := range steps {
+		_, done, err := tree.Child(st.name, st.d)
+		if !errors.Is(err, st.err) {
+			t.Fatalf("%s: expected %v, got %v", st.name, st.err, err)
+		}
+		if err == nil {
+			done()
+		}
+	}
+	if u := tree.Unallocated(); u != 0 {
+		t.Errorf("expected budget fully allocated, %v left", u)
+	}
+}
+
+func TestTimeoutTreeChildDeadlines(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	tree := newTimeoutTreeClock(context.Background(), 200*time.Millisecond, clock)
+	defer tree.Cancel()
+
+	ctx, done, err := tree.Child("fast", 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dl, _ := ctx.Deadline(); !dl.Equal(time.Unix(0, 0).Add(50 * time.Millisecond)) {
+		t.Errorf("expected child deadline 50ms after start, got %v", dl)
+	}
+	clock.Advance(20 * time.Millisecond)
+	done()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("expected done to cancel the child, got %v", ctx.Err())
+	}
+	if got := tree.Usage()["fast"]; got != 20*time.Millisecond {
+		t.Errorf("expected fast to have used 20ms, got %v", got)
+	}
+	if r := tree.Remaining(); r != 180*time.Millisecond {
+		t.Errorf("expected 180ms remaining, got %v", r)
+	}
+}
+
+func TestTimeoutTreeChildExpires(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	tree := newTimeoutTreeClock(context.Background(), time.Second, clock)
+	defer tree.Cancel()
+
+	ctx, done, err := tree.Child("db", 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer done()
+
+	clock.Advance(100 * time.Millisecond)
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", ctx.Err())
+	}
+	if err := tree.Context().Err(); err != nil {
+		t.Errorf("expected the root to outlive the child, got %v", err)
+	}
+}
+
+func TestTimeoutTreeRejectsNonPositiveChild(t *testing.T) {
+	tree := newTimeoutTreeClock(context.Background(), time.Second, NewFakeClock(time.Unix(0, 0)))
+	defer tree.Cancel()
+
+	for _, d := range []time.Duration{0, -time.Millisecond} {
+		if _, _, err := tree.Child("step", d); err == nil {
+			t.Errorf("expected an error for timeout %v", d)
+		}
+	}
+	if u := tree.Unallocated(); u != time.Second {
+		t.Errorf("expected nothing allocated, %v used", time.Second-u)
+	}
+}
+
+func TestTimeoutTreeRespectsParentDeadline(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	tree := NewTimeoutTree(parent, time.Hour)
+	defer tree.Cancel()
+
+	if b := tree.Budget(); b > 100*time.Millisecond {
+		t.Errorf("expected budget clamped to the parent's remaining time, got %v", b)
+	}
+	if _, _, err := tree.Child("slow", time.Minute); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("expected child beyond the parent's deadline to be rejected, got %v", err)
+	}
+
+	parentDL, _ := parent.Deadline()
+	ctx, done, err := tree.Child("ok", 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer done()
+	if dl, _ := ctx.Deadline(); dl.After(parentDL) {
+		t.Errorf("child deadline %v past parent deadline %v", dl, parentDL)
+	}
+}
+
+func TestTimeoutTreeChildAfterExpiry(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	tree := newTimeoutTreeClock(context.Background(), 10*time.Millisecond, clock)
+	defer tree.Cancel()
+	clock.Advance(10 * time.Millisecond)
+	<-tree.Context().Done()
+
+	if _, _, err := tree.Child("late", time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if r := tree.Remaining(); r != 0 {
+		t.Errorf("expected no time remaining, got %v", r)
+	}
+}
@@ -0,0 +1,125 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var errTokenRefreshPanicked = errors.New("token refresh panicked")
+
+// Token is an OAuth-style bearer token.
+type Token struct {
+	AccessToken string
+	TokenType   string // e.g. "Bearer"
+}
+
+// TokenRefreshFunc fetches a new token and reports when it expires.
+type TokenRefreshFunc func(ctx context.Context) (Token, time.Time, error)
+
+// TokenSource caches a token and refreshes it shortly before it expires.
+// Concurrent callers that need a refresh share a single refresh call.
+type TokenSource struct {
+	refresh TokenRefreshFunc
+	leeway  time.Duration
+	clock   Clock
+
+	mu       sync.Mutex
+	token    Token
+	expiry   time.Time
+	inflight *tokenRefresh
+}
+
+type tokenRefresh struct {
+	done chan struct{}
+	err  error
+}
+
+// NewTokenSource returns a TokenSource that refreshes through refresh once
+// the cached token is within leeway of expiring.
+func NewTokenSource(refresh TokenRefreshFunc, leeway time.Duration) *TokenSource {
+	return newTokenSourceClock(refresh, leeway, RealClock)
+}
+
+func newTokenSourceClock(refresh TokenRefreshFunc, leeway time.Duration, clock Clock) *TokenSource {
+	return &TokenSource{refresh: refresh, leeway: leeway, clock: clock}
+}
+
+// Token returns a valid access token, refreshing it if needed. If a refresh
+// fails but the cached token has not actually expired yet, the cached token
+// is returned; otherwise the refresh error is.
+//
+// The refresh runs under the context of the caller that started it; others
+// waiting on it give up when their own ctx is done.
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	for {
+		s.mu.Lock()
+		now := s.clock.Now()
+		if s.token.AccessToken != "" && now.Before(s.expiry.Add(-s.leeway)) {
+			tok := s.token.AccessToken
+			s.mu.Unlock()
+			return tok, nil
+		}
+
+		if call := s.inflight; call != nil {
+			s.mu.Unlock()
+			select {
+			case <-call.done:
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+			if call.err != nil {
+				return s.fallback(call.err)
+			}
+			continue // pick up the refreshed token
+		}
+
+		call := &tokenRefresh{done: make(chan struct{})}
+		s.inflight = call
+		s.mu.Unlock()
+
+		tok, err := s.runRefresh(ctx, call)
+		if err != nil {
+			return s.fallback(err)
+		}
+		return tok.AccessToken, nil
+	}
+}
+
+// runRefresh calls s.refresh on behalf of call and publishes the outcome.
+// The deferred cleanup releases waiters even if refresh panics.
+func (s *TokenSource) runRefresh(ctx context.Context, call *tokenRefresh) (Token, error) {
+	defer func() {
+		s.mu.Lock()
+		s.inflight = nil
+		s.mu.Unlock()
+		close(call.done)
+	}()
+	// Until refresh returns normally, treat the call as failed so a panic
+	// doesn't leave waiters blocked or let them reuse a stale result.
+	call.err = errTokenRefreshPanicked
+
+	tok, expiry, err := s.refresh(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		call.err = fmt.Errorf("refresh token: %w", err)
+		return Token{}, call.err
+	}
+	s.token, s.expiry = tok, expiry
+	call.err = nil
+	return tok, nil
+}
+
+// fallback returns the cached token if it is still unexpired, else err.
+func (s *TokenSource) fallback(err error) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token.AccessToken != "" && s.clock.Now().Before(s.expiry) {
+		return s.token.AccessToken, nil
+	}
+	return "", err
+}
@@ -0,0 +1,180 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingRefresher issues tok-1, tok-2, ... each valid for ttl of clock time.
+type countingRefresher struct {
+	clock *FakeClock
+	ttl   time.Duration
+	calls atomic.Int32
+	err   error
+	gate  chan struct{} // if non-nil, each refresh waits for it
+}
+
+func (r *countingRefresher) refresh(ctx context.Context) (Token, time.Time, error) {
+	n := r.calls.Add(1)
+	if r.gate != nil {
+		<-r.gate
+	}
+	if r.err != nil {
+		return Token{}, time.Time{}, r.err
+	}
+	return Token{AccessToken: fmt.Sprintf("tok-%d", n), TokenType: "Bearer"}, r.clock.Now().Add(r.ttl), nil
+}
+
+func TestTokenSourceRefreshesBeforeExpiry(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	r := &countingRefresher{clock: clock, ttl: time.Hour}
+	src := newTokenSourceClock(r.refresh, time.Minute, clock)
+	ctx := context.Background()
+
+	steps := []struct {
+		advance time.Duration
+		want    string
+	}{
+		{0, "tok-1"},
+		{30 * time.Minute, "tok-1"},        // still fresh
+		{28 * time.Minute, "tok-1"},        // 58m: outside the leeway
+		{90 * time.Second, "tok-2"},        // 59m30s: within a minute of expiry
+		{time.Hour - time.Minute, "tok-3"}, // tok-2 now within its leeway
+	}
+	for i, st := range steps {
+		clock.Advance(st.advance)
+		got, err := src.Token(ctx)
+		if err != nil {
+			t.Fatalf("step %d: unexpected error: %v", i, err)
+		}
+		if got != st.want {
+			t.Errorf("step %d: expected %s, got %s", i, st.want, got)
+		}
+	}
+}
+
+func TestTokenSourceDedupsConcurrentRefresh(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	r := &countingRefresher{clock: clock, ttl: time.Hour, gate: make(chan struct{})}
+	src := newTokenSourceClock(r.refresh, time.Minute, clock)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	tokens := make(chan string, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tok, err := src.Token(context.Background())
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			tokens <- tok
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(r.gate)
+	wg.Wait()
+	close(tokens)
+
+	if n := r.calls.Load(); n != 1 {
+		t.Errorf("expected 1 refresh, got %d", n)
+	}
+	for tok := range tokens {
+		if tok != "tok-1" {
+			t.Errorf("expected tok-1, got %s", tok)
+		}
+	}
+}
+
+func TestTokenSourceRefreshError(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	errAuth := errors.New("invalid client credentials")
+	r := &countingRefresher{clock: clock, ttl: time.Hour}
+	src := newTokenSourceClock(r.refresh, time.Minute, clock)
+	ctx := context.Background()
+
+	if _, err := src.Token(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r.err = errAuth
+
+	// Within the leeway but unexpired: the old token is still usable.
+	clock.Advance(59*time.Minute + 30*time.Second)
+	if got, err := src.Token(ctx); err != nil || got != "tok-1" {
+		t.Errorf("expected cached tok-1, got %q (err %v)", got, err)
+	}
+
+	// Expired: the refresh error surfaces.
+	clock.Advance(time.Minute)
+	if _, err := src.Token(ctx); !errors.Is(err, errAuth) {
+		t.Errorf("expected refresh error, got %v", err)
+	}
+}
+
+func TestTokenSourceWaiterCanceled(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	r := &countingRefresher{clock: clock, ttl: time.Hour, gate: make(chan struct{})}
+	defer close(r.gate)
+	src := newTokenSourceClock(r.refresh, time.Minute, clock)
+
+	go func() { _, _ = src.Token(context.Background()) }()
+	for r.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := src.Token(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestTokenSourceRefreshPanicReleasesWaiters(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var calls atomic.Int32
+	src := newTokenSourceClock(func(ctx context.Context) (Token, time.Time, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-release
+			panic("boom")
+		}
+		return Token{AccessToken: "tok-2"}, clock.Now().Add(time.Hour), nil
+	}, time.Minute, clock)
+
+	go func() {
+		defer func() { _ = recover() }()
+		_, _ = src.Token(context.Background())
+	}()
+	<-started
+
+	waiterErr := make(chan error, 1)
+	go func() {
+		_, err := src.Token(context.Background())
+		waiterErr <- err
+	}()
+	// The waiter is blocked on the in-flight refresh, or about to be.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	select {
+	case err := <-waiterErr:
+		if !errors.Is(err, errTokenRefreshPanicked) {
+			t.Errorf("expected errTokenRefreshPanicked, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter stayed blocked after refresh panicked")
+	}
+
+	if got, err := src.Token(context.Background()); err != nil || got != "tok-2" {
+		t.Errorf("expected a fresh refresh after the panic, got %q (err %v)", got, err)
+	}
+}
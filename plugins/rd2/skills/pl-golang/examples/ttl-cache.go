@@ -0,0 +1,110 @@
+package examples
+
+import (
+	"sync"
+	"time"
+)
+
+type ttlEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// TTLCache is a map whose entries expire after a per-entry TTL. Expired
+// entries are hidden from Get immediately and removed by a background
+// sweeper, so memory is reclaimed even for keys that are never read again.
+type TTLCache[K comparable, V any] struct {
+	mu    sync.Mutex
+	items map[K]ttlEntry[V]
+	clock Clock
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewTTLCache starts a cache whose sweeper runs every sweepInterval. A nil
+// clock means RealClock. Call Close to stop the sweeper. It panics if
+// sweepInterval is not positive.
+func NewTTLCache[K comparable, V any](sweepInterval time.Duration, clock Clock) *TTLCache[K, V] {
+	if sweepInterval <= 0 {
+		panic("examples: ttl cache sweep interval must be positive")
+	}
+	if clock == nil {
+		clock = RealClock
+	}
+	c := &TTLCache[K, V]{
+		items: make(map[K]ttlEntry[V]),
+		clock: clock,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	ticker := clock.NewTicker(sweepInterval)
+	go c.sweepLoop(ticker)
+	return c
+}
+
+// Get returns the value for k if present and not expired.
+func (c *TTLCache[K, V]) Get(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items[k]
+	if !ok || !c.clock.Now().Before(e.expires) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set stores v under k for ttl.
+func (c *TTLCache[K, V]) Set(k K, v V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[k] = ttlEntry[V]{value: v, expires: c.clock.Now().Add(ttl)}
+}
+
+// Delete removes k.
+func (c *TTLCache[K, V]) Delete(k K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, k)
+}
+
+// Len returns the number of stored entries, including expired entries the
+// sweeper has not removed yet.
+func (c *TTLCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Close stops the sweeper and waits for it to exit. It is safe to call more
+// than once.
+func (c *TTLCache[K, V]) Close() {
+	c.stopOnce.Do(func() { close(c.stop) })
+	<-c.done
+}
+
+func (c *TTLCache[K, V]) sweepLoop(ticker Ticker) {
+	defer close(c.done)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C():
+			c.sweep()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+func (c *TTLCache[K, V]) sweep() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	for k, e := range c.items {
+		if !now.Before(e.expires) {
+			delete(c.items, k)
+		}
+	}
+}
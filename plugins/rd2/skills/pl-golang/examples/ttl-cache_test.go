@@ -0,0 +1,86 @@
+package examples
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestTTLCacheGetSetDelete(t *testing.T) {
+	c := NewTTLCache[string, int](time.Minute, NewFakeClock(time.Now()))
+	defer c.Close()
+
+	c.Set("a", 1, time.Minute)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("expected (1, true), got (%d, %v)", v, ok)
+	}
+
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Error("expected deleted key to be missing")
+	}
+}
+
+func TestTTLCacheExpiry(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	c := NewTTLCache[string, string](time.Hour, clock)
+	defer c.Close()
+
+	c.Set("short", "x", 10*time.Second)
+	c.Set("long", "y", time.Minute)
+
+	clock.Advance(10 * time.Second)
+	if _, ok := c.Get("short"); ok {
+		t.Error("expected short-lived entry to be expired")
+	}
+	if _, ok := c.Get("long"); !ok {
+		t.Error("expected long-lived entry to be present")
+	}
+}
+
+func TestTTLCacheSweeperRemovesExpired(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	c := NewTTLCache[int, int](30*time.Second, clock)
+	defer c.Close()
+
+	c.Set(1, 1, 20*time.Second)
+	c.Set(2, 2, 20*time.Second)
+	c.Set(3, 3, 5*time.Minute)
+
+	clock.BlockUntil(1) // sweeper ticker armed
+	clock.Advance(30 * time.Second)
+
+	deadline := time.Now().Add(time.Second)
+	for c.Len() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected sweeper to leave 1 entry, have %d", c.Len())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if _, ok := c.Get(3); !ok {
+		t.Error("expected unexpired entry to survive the sweep")
+	}
+}
+
+func TestTTLCacheCloseStopsSweeper(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	clock := NewFakeClock(time.Now())
+
+	c := NewTTLCache[string, int](time.Second, clock)
+	c.Close()
+	c.Close()
+
+	assertNoGoroutineLeak(t, baseline)
+	if clock.Pending() != 0 {
+		t.Errorf("expected sweeper ticker to be stopped, %d pending", clock.Pending())
+	}
+}
+
+func TestNewTTLCacheInvalidSweepInterval(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for zero sweep interval")
+		}
+	}()
+	NewTTLCache[string, int](0, NewFakeClock(time.Now()))
+}
@@ -0,0 +1,56 @@
+package examples
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// NewUserServer wires the context and error patterns together into a small
+// JSON API serving GET /users/{id} from repo:
+//
+//   - RequestIDMiddleware assigns the request ID and echoes it in a header.
+//   - RecoverMiddleware turns panics into a logged, generic 500.
+//   - ContextLogger gives handlers a logger tagged with the request ID.
+//   - Handlers return CodedErrors, which WriteError maps to HTTP statuses.
+func NewUserServer(logger *slog.Logger, repo Repository[User, int64]) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", getUserHandler(repo))
+
+	return Chain(
+		RequestIDMiddleware(),
+		RecoverMiddleware(logger),
+		ContextLogger(logger),
+	)(mux)
+}
+
+func getUserHandler(repo Repository[User, int64]) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := LoggerFrom(ctx)
+
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		if err != nil {
+			WriteError(w, NewCoded(CodeInvalidArgument, "user id must be an integer"))
+			return
+		}
+
+		user, err := repo.Get(ctx, id)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			logger.InfoContext(ctx, "user not found", slog.Int64("user_id", id))
+			WriteError(w, NewCoded(CodeNotFound, "user not found"))
+			return
+		case err != nil:
+			logger.ErrorContext(ctx, "load user", slog.Int64("user_id", id), slog.Any("error", err))
+			WriteError(w, err)
+			return
+		}
+
+		logger.InfoContext(ctx, "user loaded", slog.Int64("user_id", id))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(user)
+	}
+}
@@ -0,0 +1,108 @@
+package examples
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// panickingRepo simulates a bug deep in the data layer.
+type panickingRepo struct {
+	Repository[User, int64]
+}
+
+func (panickingRepo) Get(context.Context, int64) (User, error) {
+	panic("nil pointer in row scan")
+}
+
+// logEntries decodes every JSON log line written to buf.
+func logEntries(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var entries []map[string]any
+	sc := bufio.NewScanner(buf)
+	for sc.Scan() {
+		var e map[string]any
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			t.Fatalf("invalid log line %q: %v", sc.Text(), err)
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func TestUserServer(t *testing.T) {
+	repo := NewMemoryRepository(func(u User) int64 { return u.ID })
+	if err := repo.Save(context.Background(), User{ID: 1, Name: "ada", Age: 36}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		repo   Repository[User, int64]
+		path   string
+		status int
+		code   string // expected ErrorResponse code; empty for success
+		logMsg string // empty when the request logs nothing
+	}{
+		{"found", repo, "/users/1", http.StatusOK, "", "user loaded"},
+		{"not found", repo, "/users/2", http.StatusNotFound, "NotFound", "user not found"},
+		{"invalid id", repo, "/users/abc", http.StatusBadRequest, "InvalidArgument", ""},
+		{"panic", panickingRepo{}, "/users/1", http.StatusInternalServerError, "Internal", "panic recovered"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			srv := NewUserServer(slog.New(slog.NewJSONHandler(&logs, nil)), tt.repo)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(RequestIDHeader, "req-"+tt.name)
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if got := rec.Header().Get(RequestIDHeader); got != "req-"+tt.name {
+				t.Errorf("expected request ID header %q, got %q", "req-"+tt.name, got)
+			}
+
+			if tt.code != "" {
+				var body ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+					t.Fatalf("expected JSON error body: %v", err)
+				}
+				if body.Code != tt.code {
+					t.Errorf("expected code %s, got %s", tt.code, body.Code)
+				}
+			} else {
+				var u User
+				if err := json.NewDecoder(rec.Body).Decode(&u); err != nil || u.Name != "ada" {
+					t.Errorf("expected user ada, got %+v (err %v)", u, err)
+				}
+			}
+
+			if tt.logMsg == "" {
+				return
+			}
+			for _, e := range logEntries(t, &logs) {
+				if e["msg"] != tt.logMsg {
+					continue
+				}
+				if e["request_id"] != "req-"+tt.name {
+					t.Errorf("expected request_id %q in log, got %v", "req-"+tt.name, e["request_id"])
+				}
+				if e["path"] != tt.path {
+					t.Errorf("expected path %q in log, got %v", tt.path, e["path"])
+				}
+				return
+			}
+			t.Errorf("expected log %q, got:\n%s", tt.logMsg, logs.String())
+		})
+	}
+}
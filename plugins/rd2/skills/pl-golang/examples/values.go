@@ -0,0 +1,43 @@
+package examples
+
+// Coalesce returns the first argument that is not the zero value of T, or
+// the zero value if all are zero. It suits layered config resolution:
+// Coalesce(flagValue, envValue, fileValue, defaultValue).
+func Coalesce[T comparable](vals ...T) T {
+	var zero T
+	for _, v := range vals {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
+
+// OrDefault returns v unless it is the zero value, in which case it returns
+// def.
+func OrDefault[T comparable](v, def T) T {
+	var zero T
+	if v == zero {
+		return def
+	}
+	return v
+}
+
+// As is a checked type assertion: it returns v as a T and true, or the zero
+// value and false when v is nil or holds another type. Use it instead of a
+// bare v.(T), which panics on mismatch, e.g. for ctx.Value results.
+func As[T any](v any) (T, bool) {
+	t, ok := v.(T)
+	return t, ok
+}
+
+// Must returns v, panicking if err is non-nil. Reserve it for package-level
+// initialization where failure is a programming error:
+//
+//	var tmpl = Must(template.New("page").Parse(pageHTML))
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
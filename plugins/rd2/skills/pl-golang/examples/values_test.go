@@ -0,0 +1,107 @@
+package examples
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCoalesce(t *testing.T) {
+	tests := []struct {
+		name     string
+		vals     []string
+		expected string
+	}{
+		{"first wins", []string{"a", "b"}, "a"},
+		{"skips zero", []string{"", "", "c", "d"}, "c"},
+		{"all zero", []string{"", ""}, ""},
+		{"no args", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Coalesce(tt.vals...); got != tt.expected {
+				t.Errorf("Coalesce(%q) = %q, want %q", tt.vals, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCoalesceInts(t *testing.T) {
+	if got := Coalesce(0, 0, 8080); got != 8080 {
+		t.Errorf("expected 8080, got %d", got)
+	}
+	if got := Coalesce(0, 0); got != 0 {
+		t.Errorf("expected 0, got %d", got)
+	}
+}
+
+func TestOrDefault(t *testing.T) {
+	tests := []struct {
+		name     string
+		v, def   int
+		expected int
+	}{
+		{"set", 3, 10, 3},
+		{"zero uses default", 0, 10, 10},
+		{"negative is set", -1, 10, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OrDefault(tt.v, tt.def); got != tt.expected {
+				t.Errorf("OrDefault(%d, %d) = %d, want %d", tt.v, tt.def, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAs(t *testing.T) {
+	tests := []struct {
+		name     string
+		v        any
+		expected string
+		ok       bool
+	}{
+		{"correct type", "alice", "alice", true},
+		{"wrong type", 42, "", false},
+		{"nil", nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := As[string](tt.v)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("As[string](%v) = (%q, %v), want (%q, %v)", tt.v, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestAsInterface(t *testing.T) {
+	var err any = ErrNotFound
+	if _, ok := As[error](err); !ok {
+		t.Error("expected error value to satisfy error interface")
+	}
+	if _, ok := As[error]("not an error"); ok {
+		t.Error("expected string not to satisfy error interface")
+	}
+}
+
+func TestMust(t *testing.T) {
+	if got := Must(strconv.Atoi("42")); got != 42 {
+		t.Errorf("expected 42, got %d", got)
+	}
+
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok {
+			t.Fatalf("expected panic with an error, got %v", r)
+		}
+		if !strings.Contains(err.Error(), `parsing "x"`) {
+			t.Errorf("expected panic to carry the error message, got %q", err.Error())
+		}
+	}()
+	Must(strconv.Atoi("x"))
+}
@@ -0,0 +1,170 @@
+package examples
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// WorkStealingPool runs tasks on a fixed set of workers that each own a
+// deque. Submit spreads tasks across the deques; a worker runs its own
+// tasks newest first and, when it runs dry, steals the oldest task from
+// another worker. With uneven task durations this keeps every worker busy
+// where a static split would leave some idle behind one slow task. Tasks
+// run in no particular order.
+type WorkStealingPool struct {
+	deques []*taskDeque
+	next   atomic.Uint64
+	stolen atomic.Int64
+	wake   chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+	quit   chan struct{}
+	done   chan struct{}
+}
+
+type taskDeque struct {
+	mu    sync.Mutex
+	tasks []func()
+}
+
+func (d *taskDeque) push(task func()) {
+	d.mu.Lock()
+	d.tasks = append(d.tasks, task)
+	d.mu.Unlock()
+}
+
+// pop takes the newest task; the owning worker uses it.
+func (d *taskDeque) pop() (func(), bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := len(d.tasks)
+	if n == 0 {
+		return nil, false
+	}
+	task := d.tasks[n-1]
+	d.tasks[n-1] = nil
+	d.tasks = d.tasks[:n-1]
+	return task, true
+}
+
+// steal takes the oldest task; other workers use it.
+func (d *taskDeque) steal() (func(), bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.tasks) == 0 {
+		return nil, false
+	}
+	task := d.tasks[0]
+	d.tasks[0] = nil
+	d.tasks = d.tasks[1:]
+	return task, true
+}
+
+// NewWorkStealingPool starts workers goroutines (at least one).
+func NewWorkStealingPool(workers int) *WorkStealingPool {
+	workers = max(workers, 1)
+	p := &WorkStealingPool{
+		deques: make([]*taskDeque, workers),
+		// One pending wakeup per worker is enough: a full buffer means
+		// every worker is already due to rescan the deques.
+		wake: make(chan struct{}, workers),
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	var wg sync.WaitGroup
+	for i := range p.deques {
+		p.deques[i] = &taskDeque{}
+	}
+	for i := range p.deques {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(i)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(p.done)
+	}()
+	return p
+}
+
+// Submit queues task on one of the workers' deques. It returns
+// ErrPoolClosed after Shutdown.
+func (p *WorkStealingPool) Submit(task func()) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+	i := p.next.Add(1) % uint64(len(p.deques))
+	p.deques[i].push(task)
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Stolen reports how many tasks were run by a worker other than the one
+// they were queued on.
+func (p *WorkStealingPool) Stolen() int64 {
+	return p.stolen.Load()
+}
+
+// Shutdown stops accepting tasks and waits for the workers to run every
+// queued task and exit, or for ctx to be done, in which case it returns
+// ctx.Err() and the workers keep draining in the background.
+func (p *WorkStealingPool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.quit)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *WorkStealingPool) work(id int) {
+	for {
+		if task, ok := p.take(id); ok {
+			task()
+			continue
+		}
+		select {
+		case <-p.wake:
+		case <-p.quit:
+			// Submit cannot add work once quit is closed, so an empty
+			// rescan means everything has been taken.
+			task, ok := p.take(id)
+			if !ok {
+				return
+			}
+			task()
+		}
+	}
+}
+
+// take pops from the worker's own deque, falling back to stealing from the
+// others starting with its neighbour.
+func (p *WorkStealingPool) take(id int) (func(), bool) {
+	if task, ok := p.deques[id].pop(); ok {
+		return task, true
+	}
+	for i := 1; i < len(p.deques); i++ {
+		victim := p.deques[(id+i)%len(p.deques)]
+		if task, ok := victim.steal(); ok {
+			p.stolen.Add(1)
+			return task, true
+		}
+	}
+	return nil, false
+}
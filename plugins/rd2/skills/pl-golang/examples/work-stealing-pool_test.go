@@ -0,0 +1,84 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkStealingPoolRunsAllTasks(t *testing.T) {
+	p := NewWorkStealingPool(4)
+	var ran atomic.Int64
+	for i := 0; i < 1000; i++ {
+		if err := p.Submit(func() { ran.Add(1) }); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := p.Shutdown(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := ran.Load(); n != 1000 {
+		t.Errorf("expected 1000 tasks run, got %d", n)
+	}
+	if err := p.Submit(func() {}); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("expected ErrPoolClosed after shutdown, got %v", err)
+	}
+}
+
+func TestWorkStealingPoolStealsFromBusyWorker(t *testing.T) {
+	p := NewWorkStealingPool(2)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	if err := p.Submit(func() {
+		close(started)
+		<-release
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-started
+
+	// Half of these land behind the blocked task and can only run if the
+	// free worker steals them.
+	const n = 20
+	done := make(chan struct{}, n)
+	for i := 0; i < n; i++ {
+		if err := p.Submit(func() { done <- struct{}{} }); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	for i := 0; i < n; i++ {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatalf("expected all tasks to finish while one worker is blocked, got %d", i)
+		}
+	}
+	if p.Stolen() == 0 {
+		t.Error("expected tasks to be stolen from the blocked worker")
+	}
+
+	close(release)
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWorkStealingPoolShutdownTimeout(t *testing.T) {
+	p := NewWorkStealingPool(1)
+	release := make(chan struct{})
+	defer close(release)
+	if err := p.Submit(func() { <-release }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := p.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded while a task is running, got %v", err)
+	}
+}